@@ -1,15 +1,19 @@
 package a2a
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	authimpl "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
 	common "github.com/kagent-dev/kagent/go/internal/utils"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/dlock"
 	"trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/server"
 )
@@ -24,59 +28,147 @@ type A2AHandlerMux interface {
 	RemoveAgentHandler(
 		agentRef string,
 	)
+	// SetAgentHandlerForTenant registers agentRef's handler under tenantID, so
+	// it is only reachable via /api/tenants/{tenantID}/agents/{ns}/{name}.
+	SetAgentHandlerForTenant(
+		tenantID, agentRef string,
+		client *client.A2AClient,
+		card server.AgentCard,
+	) error
+	// RemoveAgentHandlerForTenant removes a handler registered with
+	// SetAgentHandlerForTenant.
+	RemoveAgentHandlerForTenant(
+		tenantID, agentRef string,
+	)
 	http.Handler
 }
 
+// tenantHandlerKey namespaces a handler map key to a tenant so that the same
+// agentRef in two tenants never resolves to the same handler.
+func tenantHandlerKey(tenantID, agentRef string) string {
+	return tenantID + "/" + agentRef
+}
+
 type handlerMux struct {
+	// handlers and lock protect this process's in-memory view of agent
+	// handlers; they're orthogonal to locks below, which serializes the
+	// Set/Remove mutation itself across kagent controller replicas.
 	handlers       map[string]http.Handler
 	lock           sync.RWMutex
 	basePathPrefix string
 	authenticator  auth.AuthProvider
 	authorizer     auth.Authorizer
+	// policyEvaluator, when set, is consulted alongside authorizer so policy
+	// can be changed in OPA without a kagent redeploy. A deny from either
+	// one denies the request.
+	policyEvaluator authimpl.PolicyEvaluator
+	// locks, when set, serializes SetAgentHandler/RemoveAgentHandler across
+	// replicas so two controllers can't race registering the same agentRef.
+	locks *dlock.Manager
 }
 
 var _ A2AHandlerMux = &handlerMux{}
 
-// respondWithJSONError writes a JSON error response in the standard format
-func respondWithJSONError(w http.ResponseWriter, statusCode int, message string) {
+// respondWithJSONError writes err in the standard {"error":{"code":...}}
+// shape, mapping its Code to an HTTP status in one place (errors.StatusCode).
+func respondWithJSONError(w http.ResponseWriter, err *errors.KagentError) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]string{"error": message}) //nolint:errcheck
+	w.WriteHeader(errors.StatusCode(err.Code))
+	json.NewEncoder(w).Encode(err.Body()) //nolint:errcheck
 }
 
-func NewA2AHttpMux(pathPrefix string, authenticator auth.AuthProvider, authorizer auth.Authorizer) *handlerMux {
+func NewA2AHttpMux(pathPrefix string, authenticator auth.AuthProvider, authorizer auth.Authorizer, policyEvaluator authimpl.PolicyEvaluator, locks *dlock.Manager) *handlerMux {
 	return &handlerMux{
-		handlers:       make(map[string]http.Handler),
-		basePathPrefix: pathPrefix,
-		authenticator:  authenticator,
-		authorizer:     authorizer,
+		handlers:        make(map[string]http.Handler),
+		basePathPrefix:  pathPrefix,
+		authenticator:   authenticator,
+		authorizer:      authorizer,
+		policyEvaluator: policyEvaluator,
+		locks:           locks,
 	}
 }
 
+// withAgentLock runs fn while holding the distributed lock for agentRef, if
+// locks is configured; otherwise it just runs fn. Use around the mutating
+// section of SetAgentHandler/RemoveAgentHandler.
+func (a *handlerMux) withAgentLock(agentRef string, fn func() error) error {
+	if a.locks == nil {
+		return fn()
+	}
+
+	lease, err := a.locks.Acquire(context.Background(), "a2a-agent/"+agentRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock for agent %s: %w", agentRef, err)
+	}
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = lease.Release(releaseCtx)
+	}()
+
+	return fn()
+}
+
 func (a *handlerMux) SetAgentHandler(
 	agentRef string,
 	client *client.A2AClient,
 	card server.AgentCard,
 ) error {
-	srv, err := server.NewA2AServer(card, NewPassthroughManager(client), server.WithMiddleWare(authimpl.NewA2AAuthenticator(a.authenticator)))
-	if err != nil {
-		return fmt.Errorf("failed to create A2A server: %w", err)
-	}
+	return a.withAgentLock(agentRef, func() error {
+		srv, err := server.NewA2AServer(card, NewPassthroughManager(client), server.WithMiddleWare(authimpl.NewA2AAuthenticator(a.authenticator)))
+		if err != nil {
+			return fmt.Errorf("failed to create A2A server: %w", err)
+		}
 
-	a.lock.Lock()
-	defer a.lock.Unlock()
+		a.lock.Lock()
+		defer a.lock.Unlock()
 
-	a.handlers[agentRef] = srv.Handler()
+		a.handlers[agentRef] = srv.Handler()
 
-	return nil
+		return nil
+	})
 }
 
 func (a *handlerMux) RemoveAgentHandler(
 	agentRef string,
 ) {
-	a.lock.Lock()
-	defer a.lock.Unlock()
-	delete(a.handlers, agentRef)
+	_ = a.withAgentLock(agentRef, func() error {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		delete(a.handlers, agentRef)
+		return nil
+	})
+}
+
+func (a *handlerMux) SetAgentHandlerForTenant(
+	tenantID, agentRef string,
+	client *client.A2AClient,
+	card server.AgentCard,
+) error {
+	return a.withAgentLock(tenantHandlerKey(tenantID, agentRef), func() error {
+		srv, err := server.NewA2AServer(card, NewPassthroughManager(client), server.WithMiddleWare(authimpl.NewA2AAuthenticator(a.authenticator)))
+		if err != nil {
+			return fmt.Errorf("failed to create A2A server: %w", err)
+		}
+
+		a.lock.Lock()
+		defer a.lock.Unlock()
+
+		a.handlers[tenantHandlerKey(tenantID, agentRef)] = srv.Handler()
+
+		return nil
+	})
+}
+
+func (a *handlerMux) RemoveAgentHandlerForTenant(
+	tenantID, agentRef string,
+) {
+	_ = a.withAgentLock(tenantHandlerKey(tenantID, agentRef), func() error {
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		delete(a.handlers, tenantHandlerKey(tenantID, agentRef))
+		return nil
+	})
 }
 
 func (a *handlerMux) getHandler(name string) (http.Handler, bool) {
@@ -91,38 +183,64 @@ func (a *handlerMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// get the handler name from the first path segment
 	agentNamespace, ok := vars["namespace"]
 	if !ok || agentNamespace == "" {
-		respondWithJSONError(w, http.StatusBadRequest, "Agent namespace not provided")
+		respondWithJSONError(w, errors.New(errors.ErrBadInput, "Agent namespace not provided", nil))
 		return
 	}
 	agentName, ok := vars["name"]
 	if !ok || agentName == "" {
-		respondWithJSONError(w, http.StatusBadRequest, "Agent name not provided")
+		respondWithJSONError(w, errors.New(errors.ErrBadInput, "Agent name not provided", nil))
 		return
 	}
 
 	handlerName := common.ResourceRefString(agentNamespace, agentName)
 
-	// Check authorization if authorizer is configured
-	if a.authorizer != nil {
+	// Routes registered under /api/tenants/{tid}/agents/{ns}/{name} carry a
+	// tenant ID that must match the caller's own tenant, and the agent is
+	// looked up under its tenant-scoped handler key (see SetAgentHandlerForTenant).
+	if tenantID, ok := vars["tid"]; ok && tenantID != "" {
+		if callerID := authimpl.TenantIDFrom(r.Context()); callerID != tenantID {
+			respondWithJSONError(w, errors.New(errors.ErrNoPermission, "Forbidden: tenant mismatch", nil))
+			return
+		}
+		handlerName = tenantHandlerKey(tenantID, handlerName)
+	}
+
+	// Check authorization if an authorizer and/or policy evaluator is configured.
+	// Both are consulted when present; either denying denies the request.
+	if a.authorizer != nil || a.policyEvaluator != nil {
 		session, ok := auth.AuthSessionFrom(r.Context())
 		if !ok {
-			respondWithJSONError(w, http.StatusUnauthorized, "Unauthorized: no valid session found")
+			respondWithJSONError(w, errors.New(errors.ErrUnauthenticated, "Unauthorized: no valid session found", nil))
 			return
 		}
 		resource := auth.Resource{
 			Type: "Agent",
 			Name: handlerName,
 		}
-		if err := a.authorizer.Check(r.Context(), session.Principal(), auth.VerbGet, resource); err != nil {
-			respondWithJSONError(w, http.StatusForbidden, fmt.Sprintf("Forbidden: %v", err))
-			return
+		if a.authorizer != nil {
+			if err := a.authorizer.Check(r.Context(), session.Principal(), auth.VerbGet, resource); err != nil {
+				respondWithJSONError(w, errors.New(errors.ErrNoPermission, "Forbidden", err).WithField("agent", handlerName))
+				return
+			}
+		}
+		if a.policyEvaluator != nil {
+			agentRef := common.ResourceRefString(agentNamespace, agentName)
+			allowed, err := a.policyEvaluator.Evaluate(r.Context(), session.Principal(), auth.VerbGet, resource, agentRef)
+			if err != nil {
+				respondWithJSONError(w, errors.New(errors.ErrNoPermission, "Forbidden: policy evaluation failed", err).WithField("agent", handlerName))
+				return
+			}
+			if !allowed {
+				respondWithJSONError(w, errors.New(errors.ErrNoPermission, "Forbidden: denied by policy", nil).WithField("agent", handlerName))
+				return
+			}
 		}
 	}
 
 	// get the underlying handler
 	handlerHandler, ok := a.getHandler(handlerName)
 	if !ok {
-		respondWithJSONError(w, http.StatusNotFound, fmt.Sprintf("Agent %s not found", handlerName))
+		respondWithJSONError(w, errors.New(errors.ErrNotFound, fmt.Sprintf("Agent %s not found", handlerName), nil))
 		return
 	}
 
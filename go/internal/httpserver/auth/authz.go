@@ -2,14 +2,30 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	"github.com/kagent-dev/kagent/go/internal/minio"
 	"github.com/kagent-dev/kagent/go/pkg/auth"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// metadataFile is the object name RAGIndicesHandler uses to store index metadata.
+const metadataFile = ".metadata.json"
+
+// ownerRole is an admin-only role that is always permitted, regardless of
+// what a RAG index's allowedRoles/allowedUsers are configured to.
+const ownerRole = "owner"
+
+// ragIndexACL is the subset of a RAG index's .metadata.json that RBACAuthorizer
+// needs in order to make an access decision.
+type ragIndexACL struct {
+	AllowedRoles []string `json:"allowedRoles,omitempty"`
+	AllowedUsers []string `json:"allowedUsers,omitempty"`
+}
+
 type NoopAuthorizer struct{}
 
 func (a *NoopAuthorizer) Check(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource) error {
@@ -19,17 +35,25 @@ func (a *NoopAuthorizer) Check(ctx context.Context, principal auth.Principal, ve
 var _ auth.Authorizer = (*NoopAuthorizer)(nil)
 
 // RBACAuthorizer checks if a user has the required roles to access a resource.
-// Roles are defined on Agent resources via the allowedRoles field.
+// Roles are defined on Agent resources via the allowedRoles field, and on RAG
+// indices via the allowedRoles/allowedUsers fields in the index's .metadata.json.
 type RBACAuthorizer struct {
-	KubeClient client.Client
+	KubeClient  client.Client
+	MinioClient *minio.Client
 }
 
 func (a *RBACAuthorizer) Check(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource) error {
-	// Only check Agent resources for now
-	if resource.Type != "Agent" {
+	switch resource.Type {
+	case "Agent":
+		return a.checkAgent(ctx, principal, resource)
+	case "RAGIndex":
+		return a.checkRAGIndex(ctx, principal, resource)
+	default:
 		return nil
 	}
+}
 
+func (a *RBACAuthorizer) checkAgent(ctx context.Context, principal auth.Principal, resource auth.Resource) error {
 	// If no resource name specified (e.g., list operation), allow
 	// List filtering should be done separately if needed
 	if resource.Name == "" {
@@ -67,4 +91,64 @@ func (a *RBACAuthorizer) Check(ctx context.Context, principal auth.Principal, ve
 		principal.User.ID, namespace, name, agent.Spec.AllowedRoles, principal.User.Roles)
 }
 
+// checkRAGIndex authorizes access to a RAG index, where resource.Name is the
+// bucket name. An owner role is always permitted; otherwise the principal
+// must match one of the index's allowedRoles or allowedUsers.
+func (a *RBACAuthorizer) checkRAGIndex(ctx context.Context, principal auth.Principal, resource auth.Resource) error {
+	if resource.Name == "" {
+		return nil
+	}
+
+	for _, userRole := range principal.User.Roles {
+		if userRole == ownerRole {
+			return nil
+		}
+	}
+
+	if a.MinioClient == nil {
+		return fmt.Errorf("cannot authorize RAG index %s: MinIO client not configured", resource.Name)
+	}
+
+	acl, err := a.getRAGIndexACL(ctx, resource.Name)
+	if err != nil {
+		return fmt.Errorf("failed to load RAG index %s: %w", resource.Name, err)
+	}
+
+	// If no ACL configured, allow all authenticated users
+	if len(acl.AllowedRoles) == 0 && len(acl.AllowedUsers) == 0 {
+		return nil
+	}
+
+	for _, allowedUser := range acl.AllowedUsers {
+		if allowedUser == principal.User.ID {
+			return nil
+		}
+	}
+
+	for _, allowedRole := range acl.AllowedRoles {
+		for _, userRole := range principal.User.Roles {
+			if allowedRole == userRole {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("user %s does not have access to RAG index %s (allowed roles: %v, allowed users: %v, has roles: %v)",
+		principal.User.ID, resource.Name, acl.AllowedRoles, acl.AllowedUsers, principal.User.Roles)
+}
+
+func (a *RBACAuthorizer) getRAGIndexACL(ctx context.Context, bucketName string) (*ragIndexACL, error) {
+	data, err := a.MinioClient.GetObject(ctx, bucketName, metadataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata: %w", err)
+	}
+
+	var acl ragIndexACL
+	if err := json.Unmarshal(data, &acl); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return &acl, nil
+}
+
 var _ auth.Authorizer = (*RBACAuthorizer)(nil)
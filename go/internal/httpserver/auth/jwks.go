@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultJWKSTTL is how long a fetched key set is trusted before JWKSCache
+// refetches it, absent an explicit TTL.
+const defaultJWKSTTL = 15 * time.Minute
+
+// jwkSet mirrors the RFC 7517 JSON Web Key Set wire format for the subset of
+// fields RSA and ECDSA public keys need.
+type jwkSet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWKSCache fetches a JSON Web Key Set over HTTP and caches the decoded
+// public keys for TTL, so STS token validation doesn't round-trip to the
+// JWKS URL on every request.
+type JWKSCache struct {
+	url        string
+	ttl        time.Duration
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewJWKSCache creates a JWKSCache for the given JWKS URL. A zero ttl uses
+// defaultJWKSTTL.
+func NewJWKSCache(url string, ttl time.Duration) *JWKSCache {
+	if ttl <= 0 {
+		ttl = defaultJWKSTTL
+	}
+	return &JWKSCache{
+		url:        url,
+		ttl:        ttl,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Key returns the public key for kid, refreshing the cached set first if it
+// is stale or doesn't yet contain kid. If a refresh fails but a previously
+// cached key exists, the stale key is returned rather than failing outright.
+func (c *JWKSCache) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	stale := time.Since(c.fetchedAt) > c.ttl
+	c.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if err := c.refresh(ctx); err != nil {
+		if ok {
+			return key, nil
+		}
+		return nil, fmt.Errorf("jwks: failed to refresh key set: %w", err)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, ok = c.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("jwks: no key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (c *JWKSCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, c.url)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS response: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			// Skip keys we can't parse (e.g. unsupported kty) rather than
+			// failing the whole refresh over one bad entry.
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URLBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := decodeBase64URLBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curve, err := ellipticCurve(k.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := decodeBase64URLBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := decodeBase64URLBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func ellipticCurve(crv string) (elliptic.Curve, error) {
+	switch crv {
+	case "P-256":
+		return elliptic.P256(), nil
+	case "P-384":
+		return elliptic.P384(), nil
+	case "P-521":
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("unsupported EC curve %q", crv)
+	}
+}
+
+func decodeBase64URLBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+)
+
+// PolicyEvaluator makes a centralized allow/deny decision for a single
+// action, independent of (and typically layered in front of or behind) an
+// auth.Authorizer. It exists so policy can be changed (e.g. in OPA's Rego)
+// without a kagent redeploy.
+type PolicyEvaluator interface {
+	// Evaluate reports whether principal may perform verb against resource.
+	// agentRef is the namespace/name of the agent the request is acting
+	// through, if any, and is empty for requests with no agent context.
+	Evaluate(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource, agentRef string) (bool, error)
+}
+
+// OPAPolicyEvaluator evaluates requests against an Open Policy Agent
+// instance's data.kagent.allow rule.
+type OPAPolicyEvaluator struct {
+	// URL is the OPA data API endpoint to POST to, e.g.
+	// "http://opa.kagent.svc:8181/v1/data/kagent/allow".
+	URL        string
+	httpClient *http.Client
+}
+
+// NewOPAPolicyEvaluator creates an OPAPolicyEvaluator that queries url.
+func NewOPAPolicyEvaluator(url string) *OPAPolicyEvaluator {
+	return &OPAPolicyEvaluator{
+		URL:        url,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type opaRequest struct {
+	Input opaInput `json:"input"`
+}
+
+type opaInput struct {
+	Principal string   `json:"principal"`
+	Roles     []string `json:"roles,omitempty"`
+	Verb      string   `json:"verb"`
+	Resource  struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"resource"`
+	AgentRef string `json:"agent_ref,omitempty"`
+}
+
+type opaResponse struct {
+	Result bool `json:"result"`
+}
+
+// Evaluate POSTs the request context to OPA and denies unless OPA returns
+// {"result": true}.
+func (o *OPAPolicyEvaluator) Evaluate(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource, agentRef string) (bool, error) {
+	body := opaRequest{Input: opaInput{
+		Principal: principal.User.ID,
+		Roles:     principal.User.Roles,
+		Verb:      string(verb),
+		AgentRef:  agentRef,
+	}}
+	body.Input.Resource.Type = resource.Type
+	body.Input.Resource.Name = resource.Name
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal OPA request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, fmt.Errorf("failed to build OPA request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to reach OPA at %s: %w", o.URL, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("OPA returned status %d", resp.StatusCode)
+	}
+
+	var decoded opaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return false, fmt.Errorf("failed to decode OPA response: %w", err)
+	}
+
+	return decoded.Result, nil
+}
+
+var _ PolicyEvaluator = (*OPAPolicyEvaluator)(nil)
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// tenantContextKey is an unexported type to avoid collisions with context
+// keys defined in other packages.
+type tenantContextKey struct{}
+
+// DefaultTenantID is used for callers with no tenant claim, so existing
+// single-tenant deployments keep working without extra configuration.
+const DefaultTenantID = "default"
+
+// TenantIDFrom returns the tenant ID stored in ctx by TenantMiddleware, or
+// DefaultTenantID if none was resolved.
+func TenantIDFrom(ctx context.Context) string {
+	if id, ok := ctx.Value(tenantContextKey{}).(string); ok && id != "" {
+		return id
+	}
+	return DefaultTenantID
+}
+
+// WithTenantID returns a copy of ctx carrying the given tenant ID.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// tenantAware is implemented by a principal's User type when it carries a
+// tenant claim (e.g. a JWT "tenant_id" claim). User types that don't
+// implement it are treated as single-tenant, so TenantMiddleware resolves
+// DefaultTenantID until an auth.Principal's User type opts in.
+type tenantAware interface {
+	TenantID() string
+}
+
+// TenantMiddleware resolves the caller's tenant from the authenticated
+// session (falling back to DefaultTenantID) and stores it in the request
+// context for downstream handlers to scope their queries with.
+func TenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID := DefaultTenantID
+		if session, ok := AuthSessionFrom(r.Context()); ok {
+			if ta, ok := any(session.Principal().User).(tenantAware); ok {
+				if id := ta.TenantID(); id != "" {
+					tenantID = id
+				}
+			}
+		}
+		ctx := WithTenantID(r.Context(), tenantID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,246 @@
+// Package errors provides the typed error taxonomy used across the
+// httpserver: a small set of gRPC-style Codes, a KagentError that carries a
+// code, message, cause, captured stack, and arbitrary structured fields, and
+// a single place (StatusCode) that maps a Code to the HTTP status an
+// ErrorResponseWriter should respond with.
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"runtime"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Code identifies the class of failure a KagentError represents, independent
+// of the transport (HTTP status, gRPC status, or a log line) it's eventually
+// rendered through.
+type Code string
+
+const (
+	ErrValidationFailed Code = "VALIDATION_FAILED"
+	ErrInternal         Code = "INTERNAL"
+	ErrNoPermission     Code = "NO_PERMISSION"
+	ErrNotFound         Code = "NOT_FOUND"
+	ErrAlreadyExists    Code = "ALREADY_EXISTS"
+	ErrConflict         Code = "CONFLICT"
+	ErrUnimplemented    Code = "UNIMPLEMENTED"
+	ErrBadInput         Code = "BAD_INPUT"
+	ErrUnauthenticated  Code = "UNAUTHENTICATED"
+	ErrDeadlineExceeded Code = "DEADLINE_EXCEEDED"
+	ErrExternal         Code = "EXTERNAL"
+)
+
+// StatusCode maps code to the HTTP status an ErrorResponseWriter should
+// respond with. This is the one place that mapping lives; handlers should
+// not pick an http.Status themselves.
+func StatusCode(code Code) int {
+	switch code {
+	case ErrValidationFailed, ErrBadInput:
+		return http.StatusBadRequest
+	case ErrUnauthenticated:
+		return http.StatusUnauthorized
+	case ErrNoPermission:
+		return http.StatusForbidden
+	case ErrNotFound:
+		return http.StatusNotFound
+	case ErrAlreadyExists, ErrConflict:
+		return http.StatusConflict
+	case ErrUnimplemented:
+		return http.StatusNotImplemented
+	case ErrDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case ErrExternal:
+		return http.StatusBadGateway
+	case ErrInternal:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// Frame is one captured stack frame, trimmed down to what's useful to log.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// KagentError is the typed error every httpserver handler should return
+// instead of an ad-hoc fmt.Errorf. Code drives both the HTTP status
+// (StatusCode) and the JSON "code" field callers can match on; Fields carries
+// structured context (e.g. "roleName") that a handler wants logged alongside
+// the error without baking it into Message.
+type KagentError struct {
+	Code    Code
+	Message string
+	Cause   error
+	Stack   []Frame
+	Fields  map[string]any
+}
+
+// New creates a KagentError with the given code and message, capturing a
+// stack trace at the call site. cause may be nil.
+func New(code Code, message string, cause error) *KagentError {
+	return &KagentError{
+		Code:    code,
+		Message: message,
+		Cause:   cause,
+		Stack:   captureStack(2),
+	}
+}
+
+// Wrap is New with the arguments in the order a migration from fmt.Errorf
+// reads most naturally: the error being wrapped first, then the code this
+// occurrence should be classified as.
+func Wrap(cause error, code Code, message string) *KagentError {
+	return New(code, message, cause)
+}
+
+// WithField attaches a structured field to e and returns e, so calls can be
+// chained at the construction site: errors.New(...).WithField("roleName", name).
+func (e *KagentError) WithField(key string, value any) *KagentError {
+	if e.Fields == nil {
+		e.Fields = make(map[string]any)
+	}
+	e.Fields[key] = value
+	return e
+}
+
+func (e *KagentError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Unwrap exposes Cause to errors.Is/errors.As.
+func (e *KagentError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether err is (or wraps) a *KagentError carrying code.
+func Is(err error, code Code) bool {
+	var kerr *KagentError
+	if stderrors.As(err, &kerr) {
+		return kerr.Code == code
+	}
+	return false
+}
+
+func captureStack(skip int) []Frame {
+	const maxDepth = 32
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(skip+1, pcs)
+	if n == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs[:n])
+	out := make([]Frame, 0, n)
+	for {
+		frame, more := frames.Next()
+		out = append(out, Frame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// Body is the JSON shape a KagentError is rendered as:
+// {"error":{"code":"NOT_FOUND","message":"...","details":{...}}}.
+type Body struct {
+	Error BodyError `json:"error"`
+}
+
+// BodyError is the "error" object within Body.
+type BodyError struct {
+	Code    Code           `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Body renders e into the standard JSON error shape.
+func (e *KagentError) Body() Body {
+	return Body{Error: BodyError{Code: e.Code, Message: e.Message, Details: e.Fields}}
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler so structured loggers
+// emit code, message, cause, fields, and the captured stack automatically
+// whenever a KagentError is logged (e.g. zap.Any("error", kerr)).
+func (e *KagentError) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("code", string(e.Code))
+	enc.AddString("message", e.Message)
+	if e.Cause != nil {
+		enc.AddString("cause", e.Cause.Error())
+	}
+	if len(e.Fields) > 0 {
+		if err := enc.AddReflected("fields", e.Fields); err != nil {
+			return err
+		}
+	}
+	if len(e.Stack) > 0 {
+		return enc.AddArray("stack", frameArrayMarshaler(e.Stack))
+	}
+	return nil
+}
+
+type frameArrayMarshaler []Frame
+
+func (f frameArrayMarshaler) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, frame := range f {
+		enc.AppendString(fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+	}
+	return nil
+}
+
+// The constructors below are kept for every existing call site across the
+// handlers package; each just picks the Code its name has always implied.
+// New code should prefer calling New directly with an explicit Code.
+
+func NewValidationError(message string, cause error) *KagentError {
+	return New(ErrValidationFailed, message, cause)
+}
+
+func NewBadRequestError(message string, cause error) *KagentError {
+	return New(ErrBadInput, message, cause)
+}
+
+func NewUnauthorizedError(message string, cause error) *KagentError {
+	return New(ErrUnauthenticated, message, cause)
+}
+
+func NewForbiddenError(message string, cause error) *KagentError {
+	return New(ErrNoPermission, message, cause)
+}
+
+func NewNotFoundError(message string, cause error) *KagentError {
+	return New(ErrNotFound, message, cause)
+}
+
+func NewAlreadyExistsError(message string, cause error) *KagentError {
+	return New(ErrAlreadyExists, message, cause)
+}
+
+func NewConflictError(message string, cause error) *KagentError {
+	return New(ErrConflict, message, cause)
+}
+
+func NewUnimplementedError(message string, cause error) *KagentError {
+	return New(ErrUnimplemented, message, cause)
+}
+
+func NewDeadlineExceededError(message string, cause error) *KagentError {
+	return New(ErrDeadlineExceeded, message, cause)
+}
+
+func NewExternalError(message string, cause error) *KagentError {
+	return New(ErrExternal, message, cause)
+}
+
+func NewInternalServerError(message string, cause error) *KagentError {
+	return New(ErrInternal, message, cause)
+}
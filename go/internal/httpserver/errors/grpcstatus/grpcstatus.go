@@ -0,0 +1,51 @@
+// Package grpcstatus translates errors.KagentError values into
+// google.golang.org/grpc status errors, so a future gRPC surface can reuse
+// the same error taxonomy the REST handlers already construct.
+package grpcstatus
+
+import (
+	kagenterrors "github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FromKagentError converts err into a gRPC status error carrying the
+// codes.Code equivalent to err's Code and its Message. If err isn't a
+// *errors.KagentError, it's reported as codes.Unknown rather than dropping
+// the error's text.
+func FromKagentError(err error) error {
+	kerr, ok := err.(*kagenterrors.KagentError)
+	if !ok {
+		return status.New(codes.Unknown, err.Error()).Err()
+	}
+	return status.New(toGRPCCode(kerr.Code), kerr.Message).Err()
+}
+
+// toGRPCCode is the one place the Code -> codes.Code mapping lives, mirroring
+// errors.StatusCode's role for HTTP.
+func toGRPCCode(code kagenterrors.Code) codes.Code {
+	switch code {
+	case kagenterrors.ErrValidationFailed, kagenterrors.ErrBadInput:
+		return codes.InvalidArgument
+	case kagenterrors.ErrUnauthenticated:
+		return codes.Unauthenticated
+	case kagenterrors.ErrNoPermission:
+		return codes.PermissionDenied
+	case kagenterrors.ErrNotFound:
+		return codes.NotFound
+	case kagenterrors.ErrAlreadyExists:
+		return codes.AlreadyExists
+	case kagenterrors.ErrConflict:
+		return codes.Aborted
+	case kagenterrors.ErrUnimplemented:
+		return codes.Unimplemented
+	case kagenterrors.ErrDeadlineExceeded:
+		return codes.DeadlineExceeded
+	case kagenterrors.ErrExternal:
+		return codes.Unavailable
+	case kagenterrors.ErrInternal:
+		return codes.Internal
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"github.com/kagent-dev/kagent/go/pkg/dlock"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DebugHandler exposes operational introspection endpoints that aren't part
+// of the regular resource API.
+type DebugHandler struct {
+	*Base
+	// Locks, when set, backs HandleTopLocks. Nil yields an empty list rather
+	// than an error, since not every deployment runs with dlock configured.
+	Locks *dlock.Manager
+}
+
+// NewDebugHandler creates a new DebugHandler.
+func NewDebugHandler(base *Base, locks *dlock.Manager) *DebugHandler {
+	return &DebugHandler{Base: base, Locks: locks}
+}
+
+// HandleTopLocks handles GET /api/debug/locks requests, listing every
+// distributed lock this replica currently holds so operators can diagnose
+// deadlocks across kagent controller replicas.
+func (h *DebugHandler) HandleTopLocks(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("debug-handler").WithValues("operation", "top-locks")
+
+	var locks []dlock.LockInfo
+	if h.Locks != nil {
+		locks = h.Locks.TopLocks()
+	}
+
+	log.Info("Successfully listed top locks", "count", len(locks))
+	data := api.NewResponse(locks, "Successfully listed locks", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
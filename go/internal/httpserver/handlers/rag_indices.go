@@ -4,19 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
 	"github.com/kagent-dev/kagent/go/internal/minio"
+	"github.com/kagent-dev/kagent/go/internal/ragevents"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const metadataFile = ".metadata.json"
 
+// Bounds for client-requested presigned URL lifetimes.
+const (
+	defaultPresignTTL = 15 * time.Minute
+	maxPresignTTL     = 24 * time.Hour
+)
+
 // Supported file extensions for RAG document upload
 var supportedExtensions = map[string]bool{
 	".txt":  true,
@@ -30,32 +41,106 @@ var supportedExtensions = map[string]bool{
 // RAGIndicesHandler handles RAG index-related requests
 type RAGIndicesHandler struct {
 	*Base
-	MinioClient *minio.Client
+	// MinioClient is typed as the ObjectStore interface, not the concrete
+	// *minio.Client, so a decorator like *minio.EncryptedClient can be plugged
+	// in without changing this handler.
+	MinioClient minio.ObjectStore
+	Authorizer  auth.Authorizer
+	// EventDispatcher publishes document created/deleted events to each
+	// index's configured notification targets. Nil disables event publishing.
+	EventDispatcher *ragevents.Dispatcher
 }
 
 // NewRAGIndicesHandler creates a new RAGIndicesHandler
-func NewRAGIndicesHandler(base *Base, minioClient *minio.Client) *RAGIndicesHandler {
-	return &RAGIndicesHandler{Base: base, MinioClient: minioClient}
+func NewRAGIndicesHandler(base *Base, minioClient minio.ObjectStore, authorizer auth.Authorizer, eventDispatcher *ragevents.Dispatcher) *RAGIndicesHandler {
+	return &RAGIndicesHandler{Base: base, MinioClient: minioClient, Authorizer: authorizer, EventDispatcher: eventDispatcher}
+}
+
+// publishEvent dispatches a document event to the index's configured
+// notification targets, if an EventDispatcher is wired up.
+func (h *RAGIndicesHandler) publishEvent(r *http.Request, index *RAGIndex, event ragevents.Event) {
+	if h.EventDispatcher == nil || index == nil {
+		return
+	}
+	if session, ok := auth.AuthSessionFrom(r.Context()); ok {
+		event.UploadedBy = session.Principal().User.ID
+	}
+	h.EventDispatcher.Dispatch(index.Notifications, event)
 }
 
 // RAGIndex represents a RAG index stored in MinIO
 type RAGIndex struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
+	Name          string                         `json:"name"`
+	Description   string                         `json:"description,omitempty"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	AllowedRoles  []string                       `json:"allowedRoles,omitempty"`
+	AllowedUsers  []string                       `json:"allowedUsers,omitempty"`
+	Encryption    *EncryptionConfig              `json:"encryption,omitempty"`
+	Notifications []ragevents.NotificationTarget `json:"notifications,omitempty"`
+}
+
+// EncryptionConfig describes the server-side encryption applied to a RAG index's bucket.
+type EncryptionConfig struct {
+	Type     string `json:"type"` // "SSE-S3" or "SSE-KMS"
+	KMSKeyID string `json:"kmsKeyId,omitempty"`
+}
+
+// authorize checks whether the caller is permitted to perform verb against the
+// named RAG index, returning a 401/403 ErrorResponse-compatible error on denial.
+func (h *RAGIndicesHandler) authorize(r *http.Request, indexName string, verb auth.Verb) error {
+	if h.Authorizer == nil {
+		return nil
+	}
+
+	session, ok := auth.AuthSessionFrom(r.Context())
+	if !ok {
+		return errors.NewUnauthorizedError("No valid session found", nil)
+	}
+
+	resource := auth.Resource{Type: "RAGIndex", Name: indexName}
+	if err := h.Authorizer.Check(r.Context(), session.Principal(), verb, resource); err != nil {
+		return errors.NewForbiddenError("Not authorized to access RAG index '"+indexName+"'", err)
+	}
+
+	return nil
 }
 
 // RAGDocument represents a document in a RAG index
 type RAGDocument struct {
-	Name         string    `json:"name"`
-	Size         int64     `json:"size"`
-	LastModified time.Time `json:"last_modified"`
+	Name         string            `json:"name"`
+	Size         int64             `json:"size"`
+	LastModified time.Time         `json:"last_modified"`
+	VersionID    string            `json:"versionId,omitempty"`
+	IsLatest     bool              `json:"isLatest,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// DocumentVersion represents a single historical revision of a document.
+type DocumentVersion struct {
+	VersionID      string    `json:"versionId"`
+	Size           int64     `json:"size"`
+	LastModified   time.Time `json:"last_modified"`
+	IsLatest       bool      `json:"isLatest"`
+	IsDeleteMarker bool      `json:"isDeleteMarker"`
 }
 
 // CreateRAGIndexRequest represents the request body for creating a RAG index
 type CreateRAGIndexRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description,omitempty"`
+	Name          string                         `json:"name"`
+	Description   string                         `json:"description,omitempty"`
+	AllowedRoles  []string                       `json:"allowedRoles,omitempty"`
+	AllowedUsers  []string                       `json:"allowedUsers,omitempty"`
+	RetentionDays int                            `json:"retentionDays,omitempty"`
+	Encryption    *EncryptionConfig              `json:"encryption,omitempty"`
+	Notifications []ragevents.NotificationTarget `json:"notifications,omitempty"`
+}
+
+// LifecyclePolicy describes a RAG index's bucket lifecycle rule over the HTTP API.
+type LifecyclePolicy struct {
+	ExpirationDays                  int    `json:"expirationDays,omitempty"`
+	NoncurrentVersionExpirationDays int    `json:"noncurrentVersionExpirationDays,omitempty"`
+	TransitionDays                  int    `json:"transitionDays,omitempty"`
+	TransitionStorageClass          string `json:"transitionStorageClass,omitempty"`
 }
 
 // HandleListRAGIndices handles GET /api/indices requests
@@ -75,7 +160,7 @@ func (h *RAGIndicesHandler) HandleListRAGIndices(w ErrorResponseWriter, r *http.
 		return
 	}
 
-	// Filter to only RAG indices (buckets with .metadata.json)
+	// Filter to only RAG indices (buckets with .metadata.json) the caller is authorized to see
 	var indices []RAGIndex
 	for _, bucket := range buckets {
 		metadata, err := h.getIndexMetadata(r.Context(), bucket)
@@ -83,6 +168,9 @@ func (h *RAGIndicesHandler) HandleListRAGIndices(w ErrorResponseWriter, r *http.
 			// Not a RAG index, skip
 			continue
 		}
+		if err := h.authorize(r, bucket, auth.VerbList); err != nil {
+			continue
+		}
 		indices = append(indices, *metadata)
 	}
 
@@ -119,6 +207,11 @@ func (h *RAGIndicesHandler) HandleGetRAGIndex(w ErrorResponseWriter, r *http.Req
 		return
 	}
 
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
 	// Get metadata
 	metadata, err := h.getIndexMetadata(r.Context(), indexName)
 	if err != nil {
@@ -170,6 +263,11 @@ func (h *RAGIndicesHandler) HandleCreateRAGIndex(w ErrorResponseWriter, r *http.
 		return
 	}
 
+	if err := h.authorize(r, req.Name, auth.VerbCreate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
 	// Create bucket
 	if err := h.MinioClient.CreateBucket(r.Context(), req.Name); err != nil {
 		log.Error(err, "Failed to create bucket")
@@ -177,11 +275,48 @@ func (h *RAGIndicesHandler) HandleCreateRAGIndex(w ErrorResponseWriter, r *http.
 		return
 	}
 
+	// Enable versioning so uploads create new revisions instead of overwriting
+	if err := h.MinioClient.EnableVersioning(r.Context(), req.Name); err != nil {
+		log.Error(err, "Failed to enable bucket versioning")
+		_ = h.MinioClient.DeleteBucket(r.Context(), req.Name)
+		w.RespondWithError(errors.NewInternalServerError("Failed to create index", err))
+		return
+	}
+
+	// Install a default expiration lifecycle if the caller asked for one
+	if req.RetentionDays > 0 {
+		rule := minio.LifecycleRule{
+			ExpirationDays:                  req.RetentionDays,
+			NoncurrentVersionExpirationDays: req.RetentionDays,
+		}
+		if err := h.MinioClient.SetBucketLifecycle(r.Context(), req.Name, rule); err != nil {
+			log.Error(err, "Failed to set bucket lifecycle")
+			_ = h.MinioClient.DeleteBucket(r.Context(), req.Name)
+			w.RespondWithError(errors.NewInternalServerError("Failed to create index", err))
+			return
+		}
+	}
+
+	// Apply server-side encryption if requested
+	if req.Encryption != nil {
+		sseCfg := minio.EncryptionConfig{Type: req.Encryption.Type, KMSKeyID: req.Encryption.KMSKeyID}
+		if err := h.MinioClient.SetBucketEncryption(r.Context(), req.Name, sseCfg); err != nil {
+			log.Error(err, "Failed to set bucket encryption")
+			_ = h.MinioClient.DeleteBucket(r.Context(), req.Name)
+			w.RespondWithError(errors.NewBadRequestError("Failed to apply encryption config", err))
+			return
+		}
+	}
+
 	// Create metadata
 	index := RAGIndex{
-		Name:        req.Name,
-		Description: req.Description,
-		CreatedAt:   time.Now().UTC(),
+		Name:          req.Name,
+		Description:   req.Description,
+		CreatedAt:     time.Now().UTC(),
+		AllowedRoles:  req.AllowedRoles,
+		AllowedUsers:  req.AllowedUsers,
+		Encryption:    req.Encryption,
+		Notifications: req.Notifications,
 	}
 
 	// Store metadata in bucket
@@ -226,6 +361,19 @@ func (h *RAGIndicesHandler) HandleDeleteRAGIndex(w ErrorResponseWriter, r *http.
 		return
 	}
 
+	if err := h.authorize(r, indexName, auth.VerbDelete); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	// Snapshot metadata and document list before deletion so we can publish
+	// one deleted event per document afterward.
+	metadata, metaErr := h.getIndexMetadata(r.Context(), indexName)
+	var objects []minio.ObjectInfo
+	if metaErr == nil && h.EventDispatcher != nil {
+		objects, _ = h.MinioClient.ListObjectsInfo(r.Context(), indexName, "")
+	}
+
 	// Delete bucket (including all objects)
 	if err := h.MinioClient.DeleteBucket(r.Context(), indexName); err != nil {
 		log.Error(err, "Failed to delete bucket")
@@ -233,11 +381,134 @@ func (h *RAGIndicesHandler) HandleDeleteRAGIndex(w ErrorResponseWriter, r *http.
 		return
 	}
 
+	now := time.Now().UTC()
+	for _, obj := range objects {
+		if obj.Name == metadataFile {
+			continue
+		}
+		h.publishEvent(r, metadata, ragevents.Event{
+			Type:        ragevents.EventDeleted,
+			Index:       indexName,
+			Filename:    obj.Name,
+			Size:        obj.Size,
+			ContentType: obj.ContentType,
+			ETag:        obj.ETag,
+			Timestamp:   now,
+		})
+	}
+
 	log.Info("Successfully deleted RAG index")
 	data := api.NewResponse(struct{}{}, "Successfully deleted RAG index", false)
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
+// HandleGetLifecycle handles GET /api/indices/{name}/lifecycle requests
+func (h *RAGIndicesHandler) HandleGetLifecycle(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "get-lifecycle")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName)
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	rule, err := h.MinioClient.GetBucketLifecycle(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to get bucket lifecycle")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get lifecycle policy", err))
+		return
+	}
+
+	policy := LifecyclePolicy{
+		ExpirationDays:                  rule.ExpirationDays,
+		NoncurrentVersionExpirationDays: rule.NoncurrentVersionExpirationDays,
+		TransitionDays:                  rule.TransitionDays,
+		TransitionStorageClass:          rule.TransitionStorageClass,
+	}
+
+	log.Info("Successfully retrieved lifecycle policy")
+	data := api.NewResponse(policy, "Successfully retrieved lifecycle policy", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleSetLifecycle handles PUT /api/indices/{name}/lifecycle requests
+func (h *RAGIndicesHandler) HandleSetLifecycle(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "set-lifecycle")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName)
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbUpdate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	var policy LifecyclePolicy
+	if err := DecodeJSONBody(r, &policy); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+
+	rule := minio.LifecycleRule{
+		ExpirationDays:                  policy.ExpirationDays,
+		NoncurrentVersionExpirationDays: policy.NoncurrentVersionExpirationDays,
+		TransitionDays:                  policy.TransitionDays,
+		TransitionStorageClass:          policy.TransitionStorageClass,
+	}
+
+	if err := h.MinioClient.SetBucketLifecycle(r.Context(), indexName, rule); err != nil {
+		log.Error(err, "Failed to set bucket lifecycle")
+		w.RespondWithError(errors.NewInternalServerError("Failed to set lifecycle policy", err))
+		return
+	}
+
+	log.Info("Successfully set lifecycle policy")
+	data := api.NewResponse(policy, "Successfully set lifecycle policy", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
 // HandleListDocuments handles GET /api/indices/{name}/documents requests
 func (h *RAGIndicesHandler) HandleListDocuments(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "list-documents")
@@ -266,6 +537,11 @@ func (h *RAGIndicesHandler) HandleListDocuments(w ErrorResponseWriter, r *http.R
 		return
 	}
 
+	if err := h.authorize(r, indexName, auth.VerbList); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
 	// List objects in bucket
 	objects, err := h.MinioClient.ListObjectsInfo(r.Context(), indexName, "")
 	if err != nil {
@@ -274,16 +550,42 @@ func (h *RAGIndicesHandler) HandleListDocuments(w ErrorResponseWriter, r *http.R
 		return
 	}
 
-	// Filter out metadata file
+	// Optional ?tag=key=value filter, e.g. ?tag=source=wiki
+	var filterKey, filterValue string
+	if tagFilter := r.URL.Query().Get("tag"); tagFilter != "" {
+		parts := strings.SplitN(tagFilter, "=", 2)
+		if len(parts) != 2 {
+			w.RespondWithError(errors.NewBadRequestError("tag filter must be in the form key=value", nil))
+			return
+		}
+		filterKey, filterValue = parts[0], parts[1]
+		log = log.WithValues("tagFilter", tagFilter)
+	}
+
+	// Filter out metadata file, and apply the tag filter if requested
 	var documents []RAGDocument
 	for _, obj := range objects {
 		if obj.Name == metadataFile {
 			continue
 		}
+
+		var objTags map[string]string
+		if filterKey != "" {
+			objTags, err = h.MinioClient.GetObjectTags(r.Context(), indexName, obj.Name)
+			if err != nil {
+				log.Error(err, "Failed to get object tags", "document", obj.Name)
+				continue
+			}
+			if objTags[filterKey] != filterValue {
+				continue
+			}
+		}
+
 		documents = append(documents, RAGDocument{
 			Name:         obj.Name,
 			Size:         obj.Size,
 			LastModified: obj.LastModified,
+			Tags:         objTags,
 		})
 	}
 
@@ -320,6 +622,11 @@ func (h *RAGIndicesHandler) HandleUploadDocument(w ErrorResponseWriter, r *http.
 		return
 	}
 
+	if err := h.authorize(r, indexName, auth.VerbCreate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
 	// Parse multipart form (50 MB max)
 	if err := r.ParseMultipartForm(50 << 20); err != nil {
 		w.RespondWithError(errors.NewBadRequestError("Failed to parse form", err))
@@ -349,24 +656,76 @@ func (h *RAGIndicesHandler) HandleUploadDocument(w ErrorResponseWriter, r *http.
 		return
 	}
 
-	// Upload file to MinIO
+	// Upload file to MinIO, encrypting it if the index requires it
 	contentType := handler.Header.Get("Content-Type")
 	if contentType == "" {
 		contentType = "application/octet-stream"
 	}
 
-	if err := h.MinioClient.UploadFile(r.Context(), indexName, handler.Filename, file, handler.Size, contentType); err != nil {
+	metadata, err := h.getIndexMetadata(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to get index metadata")
+		w.RespondWithError(errors.NewInternalServerError("Failed to load index metadata", err))
+		return
+	}
+
+	var serverSide encrypt.ServerSide
+	if metadata.Encryption != nil {
+		sseCfg := minio.EncryptionConfig{Type: metadata.Encryption.Type, KMSKeyID: metadata.Encryption.KMSKeyID}
+		serverSide, err = minio.ServerSideEncryptionFor(sseCfg)
+		if err != nil {
+			log.Error(err, "Cannot satisfy index encryption policy")
+			w.RespondWithError(errors.NewBadRequestError("Upload rejected: this index requires encryption that cannot be applied", err))
+			return
+		}
+	}
+
+	if err := h.MinioClient.UploadFile(r.Context(), indexName, handler.Filename, file, handler.Size, contentType, serverSide); err != nil {
 		log.Error(err, "Failed to upload file to MinIO")
 		w.RespondWithError(errors.NewInternalServerError("Failed to upload file", err))
 		return
 	}
 
+	// Apply tags after upload, if the "tags" form field (a JSON object of
+	// key/value pairs) was provided. Tagging is a separate request from the
+	// upload: if the tags are invalid or cannot be applied, an error is
+	// returned but the uploaded object remains in the bucket, untagged.
+	var docTags map[string]string
+	if rawTags := r.FormValue("tags"); rawTags != "" {
+		if err := json.Unmarshal([]byte(rawTags), &docTags); err != nil {
+			w.RespondWithError(errors.NewBadRequestError("tags field must be a JSON object of string key/value pairs", err))
+			return
+		}
+		if err := h.MinioClient.SetObjectTags(r.Context(), indexName, handler.Filename, docTags); err != nil {
+			log.Error(err, "Failed to set document tags")
+			w.RespondWithError(errors.NewInternalServerError("Failed to apply document tags", err))
+			return
+		}
+	}
+
+	uploadedAt := time.Now().UTC()
+	var etag string
+	if info, err := h.MinioClient.StatObject(r.Context(), indexName, handler.Filename, ""); err == nil {
+		etag = info.ETag
+	}
+
 	doc := RAGDocument{
 		Name:         handler.Filename,
 		Size:         handler.Size,
-		LastModified: time.Now().UTC(),
+		LastModified: uploadedAt,
+		Tags:         docTags,
 	}
 
+	h.publishEvent(r, metadata, ragevents.Event{
+		Type:        ragevents.EventCreated,
+		Index:       indexName,
+		Filename:    handler.Filename,
+		Size:        handler.Size,
+		ContentType: contentType,
+		ETag:        etag,
+		Timestamp:   uploadedAt,
+	})
+
 	log.Info("Successfully uploaded document")
 	data := api.NewResponse(doc, "Successfully uploaded document", false)
 	RespondWithJSON(w, http.StatusCreated, data)
@@ -406,30 +765,678 @@ func (h *RAGIndicesHandler) HandleDownloadDocument(w ErrorResponseWriter, r *htt
 		return
 	}
 
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
 	// Don't allow downloading metadata file
 	if filename == metadataFile {
 		w.RespondWithError(errors.NewBadRequestError("Cannot download reserved file", nil))
 		return
 	}
 
-	// Get the file from MinIO
-	data, err := h.MinioClient.GetObject(r.Context(), indexName, filename)
+	versionID := r.URL.Query().Get("versionId")
+	if versionID != "" {
+		log = log.WithValues("versionId", versionID)
+	}
+
+	// Stat first so we know the full size and can validate any Range header
+	// and conditional GET headers before streaming the body.
+	info, err := h.MinioClient.StatObject(r.Context(), indexName, filename, versionID)
+	if err != nil {
+		log.Error(err, "Failed to stat file in MinIO")
+		w.RespondWithError(errors.NewNotFoundError("Document not found", err))
+		return
+	}
+
+	etag := fmt.Sprintf("%q", info.ETag)
+	if match := r.Header.Get("If-None-Match"); match != "" && match == etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if since := r.Header.Get("If-Modified-Since"); since != "" {
+		if t, err := http.ParseTime(since); err == nil && !info.LastModified.After(t) {
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	streamOpts := minio.GetObjectStreamOptions{VersionID: versionID}
+	status := http.StatusOK
+	contentLength := info.Size
+
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		start, end, ok := parseRangeHeader(rangeHeader, info.Size)
+		if !ok {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", info.Size))
+			w.RespondWithError(errors.NewBadRequestError("Invalid Range header", nil))
+			return
+		}
+		streamOpts.HasRange = true
+		streamOpts.RangeStart = start
+		streamOpts.RangeEnd = end
+		status = http.StatusPartialContent
+		contentLength = end - start + 1
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, info.Size))
+		log = log.WithValues("rangeStart", start, "rangeEnd", end)
+	}
+
+	stream, _, err := h.MinioClient.GetObjectStream(r.Context(), indexName, filename, streamOpts)
 	if err != nil {
 		log.Error(err, "Failed to get file from MinIO")
 		w.RespondWithError(errors.NewNotFoundError("Document not found", err))
 		return
 	}
+	defer stream.Close()
 
 	// Set headers for file download
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
 	w.Header().Set("Content-Type", "application/octet-stream")
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(data)))
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", contentLength))
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", info.LastModified.UTC().Format(http.TimeFormat))
+	w.WriteHeader(status)
+
+	if _, err := io.Copy(w, stream); err != nil {
+		log.Error(err, "Failed to stream document to client")
+		return
+	}
 
 	log.Info("Successfully downloaded document")
 }
 
+// parseRangeHeader parses a single-range HTTP Range header (e.g. "bytes=0-499",
+// "bytes=500-", or "bytes=-500") against the object's total size, returning the
+// inclusive byte range to serve. Multi-range requests are rejected (ok=false).
+func parseRangeHeader(header string, size int64) (start, end int64, ok bool) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return 0, 0, false
+	}
+	spec := strings.TrimPrefix(header, prefix)
+	if strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+
+	parts := strings.SplitN(spec, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	if parts[0] == "" {
+		suffixLen, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || suffixLen <= 0 {
+			return 0, 0, false
+		}
+		if suffixLen > size {
+			suffixLen = size
+		}
+		return size - suffixLen, size - 1, true
+	}
+
+	start, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+
+	if parts[1] == "" {
+		return start, size - 1, true
+	}
+
+	end, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || end < start {
+		return 0, 0, false
+	}
+	if end >= size {
+		end = size - 1
+	}
+	return start, end, true
+}
+
+// HandleGetDocumentTags handles GET /api/indices/{name}/documents/{filename}/tags requests
+func (h *RAGIndicesHandler) HandleGetDocumentTags(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "get-document-tags")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	tags, err := h.MinioClient.GetObjectTags(r.Context(), indexName, filename)
+	if err != nil {
+		log.Error(err, "Failed to get document tags")
+		w.RespondWithError(errors.NewInternalServerError("Failed to get document tags", err))
+		return
+	}
+
+	log.Info("Successfully retrieved document tags")
+	data := api.NewResponse(tags, "Successfully retrieved document tags", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleSetDocumentTags handles PUT /api/indices/{name}/documents/{filename}/tags requests.
+// The request body is a JSON object of string key/value pairs that replaces the document's
+// existing tag set.
+func (h *RAGIndicesHandler) HandleSetDocumentTags(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "set-document-tags")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbUpdate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	var tags map[string]string
+	if err := DecodeJSONBody(r, &tags); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+
+	if err := h.MinioClient.SetObjectTags(r.Context(), indexName, filename, tags); err != nil {
+		log.Error(err, "Failed to set document tags")
+		w.RespondWithError(errors.NewInternalServerError("Failed to set document tags", err))
+		return
+	}
+
+	log.Info("Successfully set document tags")
+	data := api.NewResponse(tags, "Successfully set document tags", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleListDocumentVersions handles GET /api/indices/{name}/documents/{filename}/versions requests
+func (h *RAGIndicesHandler) HandleListDocumentVersions(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "list-document-versions")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	// Check if bucket exists
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	versions, err := h.MinioClient.ListObjectVersions(r.Context(), indexName, filename)
+	if err != nil {
+		log.Error(err, "Failed to list object versions")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list document versions", err))
+		return
+	}
+	if len(versions) == 0 {
+		w.RespondWithError(errors.NewNotFoundError("Document not found", nil))
+		return
+	}
+
+	history := make([]DocumentVersion, 0, len(versions))
+	for _, v := range versions {
+		history = append(history, DocumentVersion{
+			VersionID:      v.VersionID,
+			Size:           v.Size,
+			LastModified:   v.LastModified,
+			IsLatest:       v.IsLatest,
+			IsDeleteMarker: v.IsDeleteMarker,
+		})
+	}
+
+	log.Info("Successfully listed document versions", "count", len(history))
+	data := api.NewResponse(history, "Successfully listed document versions", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleDeleteDocumentVersion handles DELETE /api/indices/{name}/documents/{filename}/versions/{versionId} requests.
+// It permanently removes the given revision, leaving every other version intact; if the given
+// revision is the latest one, it is removed as well.
+func (h *RAGIndicesHandler) HandleDeleteDocumentVersion(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "delete-document-version")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	versionID, err := GetPathParam(r, "versionId")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get version ID from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename, "versionId", versionID)
+
+	// Check if bucket exists
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbDelete); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	if err := h.MinioClient.RemoveObjectVersion(r.Context(), indexName, filename, versionID); err != nil {
+		log.Error(err, "Failed to remove object version")
+		w.RespondWithError(errors.NewInternalServerError("Failed to delete document version", err))
+		return
+	}
+
+	log.Info("Successfully deleted document version")
+	data := api.NewResponse(struct{}{}, "Successfully deleted document version", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleDeleteDocument handles DELETE /api/indices/{name}/documents/{filename} requests.
+// On a versioned bucket this leaves a delete marker behind rather than purging history;
+// use HandleDeleteDocumentVersion to remove a specific revision permanently.
+func (h *RAGIndicesHandler) HandleDeleteDocument(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "delete-document")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	// Check if bucket exists
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbDelete); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	info, statErr := h.MinioClient.StatObject(r.Context(), indexName, filename, "")
+	if statErr != nil {
+		w.RespondWithError(errors.NewNotFoundError("Document not found", nil))
+		return
+	}
+
+	if err := h.MinioClient.DeleteFile(r.Context(), indexName, filename); err != nil {
+		log.Error(err, "Failed to delete document")
+		w.RespondWithError(errors.NewInternalServerError("Failed to delete document", err))
+		return
+	}
+
+	if metadata, err := h.getIndexMetadata(r.Context(), indexName); err == nil {
+		h.publishEvent(r, metadata, ragevents.Event{
+			Type:        ragevents.EventDeleted,
+			Index:       indexName,
+			Filename:    filename,
+			Size:        info.Size,
+			ContentType: info.ContentType,
+			ETag:        info.ETag,
+			Timestamp:   time.Now().UTC(),
+		})
+	}
+
+	log.Info("Successfully deleted document")
+	data := api.NewResponse(struct{}{}, "Successfully deleted document", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// PresignUploadRequest represents the request body for requesting a presigned upload URL.
+// ContentType is accepted for client bookkeeping only: the presigned PUT URL does not
+// enforce it, since minio-go's PresignedPutObject doesn't sign a Content-Type constraint.
+type PresignUploadRequest struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType,omitempty"`
+	TTLSeconds  int    `json:"ttlSeconds,omitempty"`
+}
+
+// PresignResponse represents a presigned URL and its expiry
+type PresignResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CompleteUploadRequest represents the request body for confirming a presigned upload finished
+type CompleteUploadRequest struct {
+	Size        int64  `json:"size,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// presignTTL clamps a client-requested TTL (in seconds) to the server's allowed range
+func presignTTL(requestedSeconds int) time.Duration {
+	if requestedSeconds <= 0 {
+		return defaultPresignTTL
+	}
+	ttl := time.Duration(requestedSeconds) * time.Second
+	if ttl > maxPresignTTL {
+		return maxPresignTTL
+	}
+	return ttl
+}
+
+// HandleGeneratePresignedUpload handles POST /api/indices/{name}/presign-upload requests
+func (h *RAGIndicesHandler) HandleGeneratePresignedUpload(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "presign-upload")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName)
+
+	var req PresignUploadRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+
+	if req.Filename == "" {
+		w.RespondWithError(errors.NewBadRequestError("Filename is required", nil))
+		return
+	}
+	if req.Filename == metadataFile {
+		w.RespondWithError(errors.NewBadRequestError("Cannot upload file with reserved name", nil))
+		return
+	}
+
+	ext := strings.ToLower(filepath.Ext(req.Filename))
+	if !supportedExtensions[ext] {
+		w.RespondWithError(errors.NewBadRequestError(
+			fmt.Sprintf("Unsupported file type '%s'. Supported types: .txt, .md, .json, .csv, .docx, .pdf", ext), nil))
+		return
+	}
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbCreate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	ttl := presignTTL(req.TTLSeconds)
+	url, err := h.MinioClient.PresignPut(r.Context(), indexName, req.Filename, ttl)
+	if err != nil {
+		log.Error(err, "Failed to presign upload")
+		w.RespondWithError(errors.NewInternalServerError("Failed to generate presigned upload URL", err))
+		return
+	}
+
+	log.Info("Successfully generated presigned upload URL", "fileName", req.Filename)
+	resp := PresignResponse{URL: url, ExpiresAt: time.Now().UTC().Add(ttl)}
+	data := api.NewResponse(resp, "Successfully generated presigned upload URL", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleGeneratePresignedDownload handles GET /api/indices/{name}/documents/{filename}/presign requests
+func (h *RAGIndicesHandler) HandleGeneratePresignedDownload(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "presign-download")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	if filename == metadataFile {
+		w.RespondWithError(errors.NewBadRequestError("Cannot download reserved file", nil))
+		return
+	}
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	ttlSeconds := 0
+	if v := r.URL.Query().Get("ttlSeconds"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			ttlSeconds = parsed
+		}
+	}
+	ttl := presignTTL(ttlSeconds)
+	disposition := fmt.Sprintf("attachment; filename=\"%s\"", filename)
+
+	url, err := h.MinioClient.PresignGet(r.Context(), indexName, filename, ttl, disposition)
+	if err != nil {
+		log.Error(err, "Failed to presign download")
+		w.RespondWithError(errors.NewInternalServerError("Failed to generate presigned download URL", err))
+		return
+	}
+
+	log.Info("Successfully generated presigned download URL")
+	resp := PresignResponse{URL: url, ExpiresAt: time.Now().UTC().Add(ttl)}
+	data := api.NewResponse(resp, "Successfully generated presigned download URL", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleCompleteUpload handles POST /api/indices/{name}/documents/{filename}/complete requests.
+// Clients call this after finishing a direct PUT to a presigned upload URL so the server can
+// register the document (e.g. triggering downstream indexing) once the object actually exists.
+func (h *RAGIndicesHandler) HandleCompleteUpload(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("rag-indices-handler").WithValues("operation", "complete-upload")
+
+	if h.MinioClient == nil {
+		w.RespondWithError(errors.NewInternalServerError("MinIO client not configured", nil))
+		return
+	}
+
+	indexName, err := GetPathParam(r, "name")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get index name from path", err))
+		return
+	}
+	filename, err := GetPathParam(r, "filename")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get filename from path", err))
+		return
+	}
+	log = log.WithValues("indexName", indexName, "filename", filename)
+
+	var req CompleteUploadRequest
+	_ = DecodeJSONBody(r, &req) // body is optional
+
+	exists, err := h.MinioClient.BucketExists(r.Context(), indexName)
+	if err != nil {
+		log.Error(err, "Failed to check bucket existence")
+		w.RespondWithError(errors.NewInternalServerError("Failed to check index", err))
+		return
+	}
+	if !exists {
+		w.RespondWithError(errors.NewNotFoundError("RAG index not found", nil))
+		return
+	}
+
+	if err := h.authorize(r, indexName, auth.VerbCreate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	objects, err := h.MinioClient.ListObjectsInfo(r.Context(), indexName, filename)
+	if err != nil {
+		log.Error(err, "Failed to verify uploaded object")
+		w.RespondWithError(errors.NewInternalServerError("Failed to verify uploaded document", err))
+		return
+	}
+
+	var doc *RAGDocument
+	var etag string
+	for _, obj := range objects {
+		if obj.Name == filename {
+			doc = &RAGDocument{Name: obj.Name, Size: obj.Size, LastModified: obj.LastModified}
+			etag = obj.ETag
+			break
+		}
+	}
+	if doc == nil {
+		w.RespondWithError(errors.NewNotFoundError("Document was not found; upload may not have completed", nil))
+		return
+	}
+
+	if metadata, err := h.getIndexMetadata(r.Context(), indexName); err == nil {
+		h.publishEvent(r, metadata, ragevents.Event{
+			Type:        ragevents.EventCreated,
+			Index:       indexName,
+			Filename:    doc.Name,
+			Size:        doc.Size,
+			ContentType: req.ContentType,
+			ETag:        etag,
+			Timestamp:   doc.LastModified,
+		})
+	}
+
+	log.Info("Successfully confirmed document upload")
+	data := api.NewResponse(*doc, "Successfully confirmed document upload", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
 // getIndexMetadata retrieves the metadata for an index from MinIO
 func (h *RAGIndicesHandler) getIndexMetadata(ctx context.Context, bucketName string) (*RAGIndex, error) {
 	data, err := h.MinioClient.GetObject(ctx, bucketName, metadataFile)
@@ -0,0 +1,72 @@
+package handlers
+
+// This file intentionally tests only RAGIndicesHandler.authorize in
+// isolation, not the HandleGeneratePresignedUpload/Download/CompleteUpload
+// handlers that call it. Those handlers (and every other handler in this
+// package) are built on Base, ErrorResponseWriter, and the request-routing
+// helpers (GetPathParam, DecodeJSONBody, RespondWithJSON) referenced
+// throughout this package's source files, plus the full github.com/kagent-dev/kagent/go/pkg/auth
+// package. None of those are present in this checkout, so a test that drives
+// a handler end-to-end would have to invent their shapes rather than verify
+// against the real ones. authorize itself only touches h.Authorizer and
+// auth.AuthSessionFrom, so it can be exercised directly without any of that
+// missing scaffolding.
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+)
+
+// stubAuthorizer lets a test assert whether Check was reached at all; the
+// cases below never need it to actually evaluate a decision.
+type stubAuthorizer struct {
+	called bool
+}
+
+func (a *stubAuthorizer) Check(ctx context.Context, principal auth.Principal, verb auth.Verb, resource auth.Resource) error {
+	a.called = true
+	return nil
+}
+
+func TestRAGIndicesHandlerAuthorizeAllowsWhenNoAuthorizerConfigured(t *testing.T) {
+	h := &RAGIndicesHandler{}
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	if err := h.authorize(req, "my-index", auth.VerbGet); err != nil {
+		t.Fatalf("authorize with nil Authorizer should allow, got: %v", err)
+	}
+}
+
+func TestRAGIndicesHandlerAuthorizeDeniesRequestWithNoSession(t *testing.T) {
+	stub := &stubAuthorizer{}
+	h := &RAGIndicesHandler{Authorizer: stub}
+
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	err = h.authorize(req, "my-index", auth.VerbGet)
+	if err == nil {
+		t.Fatal("authorize should deny a request carrying no auth session")
+	}
+
+	kerr, ok := err.(*errors.KagentError)
+	if !ok {
+		t.Fatalf("expected *errors.KagentError, got %T", err)
+	}
+	if kerr.Code != errors.ErrUnauthenticated {
+		t.Fatalf("expected ErrUnauthenticated, got %v", kerr.Code)
+	}
+	if stub.called {
+		t.Fatal("Authorizer.Check should not be reached when no session is present")
+	}
+}
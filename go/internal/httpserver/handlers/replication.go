@@ -0,0 +1,654 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/internal/minio"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"github.com/kagent-dev/kagent/go/pkg/database"
+	"github.com/kagent-dev/kagent/go/pkg/dlock"
+	"github.com/robfig/cron/v3"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Replication job statuses, mirrored into every database.ReplicationJob this
+// handler writes.
+const (
+	replicationJobStatusRunning   = "running"
+	replicationJobStatusSucceeded = "succeeded"
+	replicationJobStatusFailed    = "failed"
+)
+
+// maxReplicationLogLines bounds how much of a run's log is kept in a job's
+// LogTail, so a long-running policy with many objects doesn't grow a job
+// record without bound.
+const maxReplicationLogLines = 200
+
+// ReplicationCredentialsResolver resolves a policy's TargetCredentialsRef
+// (e.g. a secret name) to the actual access key/secret pair to use when
+// connecting to the target endpoint, so raw credentials are never stored in
+// database.ReplicationPolicy itself.
+type ReplicationCredentialsResolver interface {
+	Resolve(ctx context.Context, credentialsRef string) (accessKeyID, secretAccessKey string, err error)
+}
+
+// ReplicationHandler serves the replication policy/job API and owns the
+// background cron scheduler that runs enabled policies on their configured
+// schedule.
+type ReplicationHandler struct {
+	*Base
+	Replicator          *minio.Replicator
+	CredentialsResolver ReplicationCredentialsResolver
+	// Authorizer, when set, is consulted on every policy/job operation. A
+	// replication policy can point its target at an arbitrary external MinIO
+	// endpoint and copy a source bucket's contents there, so (unlike
+	// RolesHandler's PolicyEvaluator, which only gates mutations) this is
+	// checked on reads too.
+	Authorizer auth.Authorizer
+	// Locks serializes mutating policy operations and policy runs across
+	// kagent controller replicas. Nil disables cross-replica serialization.
+	Locks *dlock.Manager
+
+	cron *cron.Cron
+
+	mu      sync.Mutex
+	entries map[string]cron.EntryID // policy ID -> scheduled cron entry
+}
+
+// NewReplicationHandler creates a ReplicationHandler. Call StartScheduler
+// once at server startup to load and schedule any already-enabled policies.
+func NewReplicationHandler(base *Base, replicator *minio.Replicator, credentialsResolver ReplicationCredentialsResolver, authorizer auth.Authorizer, locks *dlock.Manager) *ReplicationHandler {
+	h := &ReplicationHandler{
+		Base:                base,
+		Replicator:          replicator,
+		CredentialsResolver: credentialsResolver,
+		Authorizer:          authorizer,
+		Locks:               locks,
+		cron:                cron.New(),
+		entries:             make(map[string]cron.EntryID),
+	}
+	h.cron.Start()
+	return h
+}
+
+// authorize checks whether the caller is permitted to perform verb against
+// the named replication policy, returning a 401/403 ErrorResponse-compatible
+// error on denial. A nil Authorizer allows the request.
+func (h *ReplicationHandler) authorize(r *http.Request, policyID string, verb auth.Verb) error {
+	if h.Authorizer == nil {
+		return nil
+	}
+
+	session, ok := auth.AuthSessionFrom(r.Context())
+	if !ok {
+		return errors.NewUnauthorizedError("No valid session found", nil)
+	}
+
+	resource := auth.Resource{Type: "ReplicationPolicy", Name: policyID}
+	if err := h.Authorizer.Check(r.Context(), session.Principal(), verb, resource); err != nil {
+		return errors.NewForbiddenError("Not authorized to access replication policy '"+policyID+"'", err)
+	}
+
+	return nil
+}
+
+// StartScheduler loads every enabled replication policy from the database
+// and schedules it on the background cron. It should be called once, after
+// construction.
+func (h *ReplicationHandler) StartScheduler(ctx context.Context) error {
+	log := ctrllog.FromContext(ctx).WithName("replication-handler").WithValues("operation", "start-scheduler")
+
+	policies, err := h.DatabaseService.ListReplicationPolicies()
+	if err != nil {
+		return fmt.Errorf("failed to list replication policies: %w", err)
+	}
+
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+		if err := h.schedule(policy); err != nil {
+			log.Error(err, "Failed to schedule replication policy", "policyId", policy.ID)
+		}
+	}
+
+	return nil
+}
+
+// schedule (re)registers policy on the cron scheduler, replacing any
+// existing entry for the same policy ID.
+func (h *ReplicationHandler) schedule(policy *database.ReplicationPolicy) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if entryID, ok := h.entries[policy.ID]; ok {
+		h.cron.Remove(entryID)
+		delete(h.entries, policy.ID)
+	}
+
+	policyID := policy.ID
+	entryID, err := h.cron.AddFunc(policy.CronExpression, func() {
+		h.runPolicy(context.Background(), policyID, "scheduler")
+	})
+	if err != nil {
+		return fmt.Errorf("invalid cron expression %q: %w", policy.CronExpression, err)
+	}
+
+	h.entries[policyID] = entryID
+	return nil
+}
+
+// unschedule removes policyID's cron entry, if any.
+func (h *ReplicationHandler) unschedule(policyID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if entryID, ok := h.entries[policyID]; ok {
+		h.cron.Remove(entryID)
+		delete(h.entries, policyID)
+	}
+}
+
+// lockPolicy acquires a distributed lock scoped to policyID, if Locks is
+// configured, and returns a release func safe to defer unconditionally.
+func (h *ReplicationHandler) lockPolicy(r *http.Request, policyID string) (context.Context, func(), error) {
+	if h.Locks == nil {
+		return r.Context(), func() {}, nil
+	}
+
+	lease, err := h.Locks.Acquire(r.Context(), "replication-policy/"+policyID)
+	if err != nil {
+		return nil, func() {}, errors.NewConflictError("Replication policy is locked by another operation", err)
+	}
+
+	release := func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := lease.Release(releaseCtx); err != nil {
+			ctrllog.FromContext(r.Context()).Error(err, "failed to release replication policy lock", "policyId", policyID)
+		}
+	}
+
+	return lease.Context(), release, nil
+}
+
+// ReplicationPolicyRequest is the request body for creating or updating a
+// replication policy.
+type ReplicationPolicyRequest struct {
+	Name                 string `json:"name"`
+	SourceBucket         string `json:"sourceBucket"`
+	TargetBucket         string `json:"targetBucket,omitempty"`
+	TargetEndpoint       string `json:"targetEndpoint"`
+	TargetCredentialsRef string `json:"targetCredentialsRef"`
+	CronExpression       string `json:"cronExpression"`
+	Enabled              bool   `json:"enabled"`
+}
+
+// validate checks the fields common to create and update requests.
+func (req ReplicationPolicyRequest) validate() error {
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if req.SourceBucket == "" {
+		return fmt.Errorf("sourceBucket is required")
+	}
+	if req.TargetEndpoint == "" {
+		return fmt.Errorf("targetEndpoint is required")
+	}
+	if req.TargetCredentialsRef == "" {
+		return fmt.Errorf("targetCredentialsRef is required")
+	}
+	if req.CronExpression == "" {
+		return fmt.Errorf("cronExpression is required")
+	}
+	if _, err := cron.ParseStandard(req.CronExpression); err != nil {
+		return fmt.Errorf("invalid cronExpression: %w", err)
+	}
+	return nil
+}
+
+// HandleListReplicationPolicies handles GET /api/replication/policies requests
+func (h *ReplicationHandler) HandleListReplicationPolicies(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "list")
+
+	policies, err := h.DatabaseService.ListReplicationPolicies()
+	if err != nil {
+		log.Error(err, "Failed to list replication policies")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list replication policies", err))
+		return
+	}
+
+	// Filter to only the policies the caller is authorized to see.
+	visible := make([]*database.ReplicationPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if err := h.authorize(r, policy.ID, auth.VerbList); err != nil {
+			continue
+		}
+		visible = append(visible, policy)
+	}
+
+	log.Info("Successfully listed replication policies", "count", len(visible))
+	data := api.NewResponse(visible, "Successfully listed replication policies", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleGetReplicationPolicy handles GET /api/replication/policies/{id} requests
+func (h *ReplicationHandler) HandleGetReplicationPolicy(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "get")
+
+	policyID, err := GetPathParam(r, "id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get policy id from path", err))
+		return
+	}
+	log = log.WithValues("policyId", policyID)
+
+	policy, err := h.DatabaseService.GetReplicationPolicy(policyID)
+	if err != nil {
+		log.Error(err, "Failed to get replication policy")
+		w.RespondWithError(errors.NewNotFoundError("Replication policy not found", err))
+		return
+	}
+
+	if err := h.authorize(r, policyID, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	log.Info("Successfully retrieved replication policy")
+	data := api.NewResponse(policy, "Successfully retrieved replication policy", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleCreateReplicationPolicy handles POST /api/replication/policies requests
+func (h *ReplicationHandler) HandleCreateReplicationPolicy(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "create")
+
+	var req ReplicationPolicyRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
+	if err := h.authorize(r, req.Name, auth.VerbCreate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	policyID, err := randomToken(8)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to generate policy id", err))
+		return
+	}
+
+	lockCtx, unlock, err := h.lockPolicy(r, policyID)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
+	targetBucket := req.TargetBucket
+	if targetBucket == "" {
+		targetBucket = req.SourceBucket
+	}
+
+	policy := &database.ReplicationPolicy{
+		ID:                   policyID,
+		Name:                 req.Name,
+		SourceBucket:         req.SourceBucket,
+		TargetBucket:         targetBucket,
+		TargetEndpoint:       req.TargetEndpoint,
+		TargetCredentialsRef: req.TargetCredentialsRef,
+		CronExpression:       req.CronExpression,
+		Enabled:              req.Enabled,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.NewConflictError("Replication policy lock was lost before the policy could be created", lockCtx.Err()))
+		return
+	}
+
+	if err := h.DatabaseService.StoreReplicationPolicy(policy); err != nil {
+		log.Error(err, "Failed to create replication policy")
+		w.RespondWithError(errors.NewInternalServerError("Failed to create replication policy", err))
+		return
+	}
+
+	if policy.Enabled {
+		if err := h.schedule(policy); err != nil {
+			log.Error(err, "Failed to schedule replication policy")
+			w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+			return
+		}
+	}
+
+	log.Info("Successfully created replication policy", "policyId", policy.ID)
+	data := api.NewResponse(policy, "Successfully created replication policy", false)
+	RespondWithJSON(w, http.StatusCreated, data)
+}
+
+// HandleUpdateReplicationPolicy handles PUT /api/replication/policies/{id} requests
+func (h *ReplicationHandler) HandleUpdateReplicationPolicy(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "update")
+
+	policyID, err := GetPathParam(r, "id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get policy id from path", err))
+		return
+	}
+	log = log.WithValues("policyId", policyID)
+
+	existing, err := h.DatabaseService.GetReplicationPolicy(policyID)
+	if err != nil {
+		log.Error(err, "Failed to get replication policy")
+		w.RespondWithError(errors.NewNotFoundError("Replication policy not found", err))
+		return
+	}
+
+	if err := h.authorize(r, policyID, auth.VerbUpdate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	lockCtx, unlock, err := h.lockPolicy(r, policyID)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
+	var req ReplicationPolicyRequest
+	if err := DecodeJSONBody(r, &req); err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		return
+	}
+	if err := req.validate(); err != nil {
+		w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+		return
+	}
+
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.NewConflictError("Replication policy lock was lost before the update could be applied", lockCtx.Err()))
+		return
+	}
+
+	targetBucket := req.TargetBucket
+	if targetBucket == "" {
+		targetBucket = req.SourceBucket
+	}
+
+	existing.Name = req.Name
+	existing.SourceBucket = req.SourceBucket
+	existing.TargetBucket = targetBucket
+	existing.TargetEndpoint = req.TargetEndpoint
+	existing.TargetCredentialsRef = req.TargetCredentialsRef
+	existing.CronExpression = req.CronExpression
+	existing.Enabled = req.Enabled
+	existing.UpdatedAt = time.Now()
+
+	if err := h.DatabaseService.StoreReplicationPolicy(existing); err != nil {
+		log.Error(err, "Failed to update replication policy")
+		w.RespondWithError(errors.NewInternalServerError("Failed to update replication policy", err))
+		return
+	}
+
+	if existing.Enabled {
+		if err := h.schedule(existing); err != nil {
+			log.Error(err, "Failed to reschedule replication policy")
+			w.RespondWithError(errors.NewBadRequestError(err.Error(), nil))
+			return
+		}
+	} else {
+		h.unschedule(existing.ID)
+	}
+
+	log.Info("Successfully updated replication policy")
+	data := api.NewResponse(existing, "Successfully updated replication policy", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleDeleteReplicationPolicy handles DELETE /api/replication/policies/{id} requests
+func (h *ReplicationHandler) HandleDeleteReplicationPolicy(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "delete")
+
+	policyID, err := GetPathParam(r, "id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get policy id from path", err))
+		return
+	}
+	log = log.WithValues("policyId", policyID)
+
+	if _, err := h.DatabaseService.GetReplicationPolicy(policyID); err != nil {
+		log.Error(err, "Failed to get replication policy")
+		w.RespondWithError(errors.NewNotFoundError("Replication policy not found", err))
+		return
+	}
+
+	if err := h.authorize(r, policyID, auth.VerbDelete); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	lockCtx, unlock, err := h.lockPolicy(r, policyID)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.NewConflictError("Replication policy lock was lost before the policy could be deleted", lockCtx.Err()))
+		return
+	}
+
+	h.unschedule(policyID)
+
+	if err := h.DatabaseService.DeleteReplicationPolicy(policyID); err != nil {
+		log.Error(err, "Failed to delete replication policy")
+		w.RespondWithError(errors.NewInternalServerError("Failed to delete replication policy", err))
+		return
+	}
+
+	log.Info("Successfully deleted replication policy")
+	data := api.NewResponse(struct{}{}, "Successfully deleted replication policy", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleListReplicationJobs handles GET /api/replication/policies/{id}/jobs requests
+func (h *ReplicationHandler) HandleListReplicationJobs(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "list-jobs")
+
+	policyID, err := GetPathParam(r, "id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get policy id from path", err))
+		return
+	}
+	log = log.WithValues("policyId", policyID)
+
+	if _, err := h.DatabaseService.GetReplicationPolicy(policyID); err != nil {
+		log.Error(err, "Failed to get replication policy")
+		w.RespondWithError(errors.NewNotFoundError("Replication policy not found", err))
+		return
+	}
+
+	if err := h.authorize(r, policyID, auth.VerbGet); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	jobs, err := h.DatabaseService.ListReplicationJobs(policyID)
+	if err != nil {
+		log.Error(err, "Failed to list replication jobs")
+		w.RespondWithError(errors.NewInternalServerError("Failed to list replication jobs", err))
+		return
+	}
+
+	log.Info("Successfully listed replication jobs", "count", len(jobs))
+	data := api.NewResponse(jobs, "Successfully listed replication jobs", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// HandleTriggerReplication handles POST /api/replication/policies/{id}/trigger
+// requests. The run happens out-of-band; the response only confirms the job
+// was accepted, not that it succeeded -- poll HandleListReplicationJobs for
+// the outcome.
+func (h *ReplicationHandler) HandleTriggerReplication(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("replication-handler").WithValues("operation", "trigger")
+
+	policyID, err := GetPathParam(r, "id")
+	if err != nil {
+		w.RespondWithError(errors.NewBadRequestError("Failed to get policy id from path", err))
+		return
+	}
+	log = log.WithValues("policyId", policyID)
+
+	if _, err := h.DatabaseService.GetReplicationPolicy(policyID); err != nil {
+		log.Error(err, "Failed to get replication policy")
+		w.RespondWithError(errors.NewNotFoundError("Replication policy not found", err))
+		return
+	}
+
+	if err := h.authorize(r, policyID, auth.VerbUpdate); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	triggeredBy := "manual"
+	if session, ok := auth.AuthSessionFrom(r.Context()); ok {
+		triggeredBy = session.Principal().User.ID
+	}
+
+	go h.runPolicy(context.Background(), policyID, triggeredBy)
+
+	log.Info("Accepted manual replication trigger", "triggeredBy", triggeredBy)
+	data := api.NewResponse(struct{}{}, "Replication run triggered", false)
+	RespondWithJSON(w, http.StatusAccepted, data)
+}
+
+// runPolicy executes one run of policyID, persisting a database.ReplicationJob
+// recording its outcome. It's invoked both by the cron scheduler and by
+// HandleTriggerReplication.
+func (h *ReplicationHandler) runPolicy(ctx context.Context, policyID, triggeredBy string) {
+	log := ctrllog.FromContext(ctx).WithName("replication-handler").WithValues("operation", "run", "policyId", policyID)
+
+	if h.Locks != nil {
+		lease, err := h.Locks.Acquire(ctx, "replication-policy/"+policyID)
+		if err != nil {
+			log.Info("Skipping replication run: policy is locked by another operation", "error", err.Error())
+			return
+		}
+		defer func() {
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := lease.Release(releaseCtx); err != nil {
+				log.Error(err, "failed to release replication policy lock")
+			}
+		}()
+		ctx = lease.Context()
+	}
+
+	policy, err := h.DatabaseService.GetReplicationPolicy(policyID)
+	if err != nil {
+		log.Error(err, "Failed to load replication policy for run")
+		return
+	}
+
+	jobID, err := randomToken(8)
+	if err != nil {
+		log.Error(err, "Failed to generate replication job id")
+		return
+	}
+
+	startedAt := time.Now()
+	job := &database.ReplicationJob{
+		ID:          jobID,
+		PolicyID:    policyID,
+		Status:      replicationJobStatusRunning,
+		TriggeredBy: triggeredBy,
+		StartedAt:   startedAt,
+	}
+	if err := h.DatabaseService.StoreReplicationJob(job); err != nil {
+		log.Error(err, "Failed to persist replication job start")
+		return
+	}
+
+	var logLines []string
+	logFn := func(format string, args ...any) {
+		line := fmt.Sprintf(format, args...)
+		log.Info(line)
+		logLines = append(logLines, line)
+		if len(logLines) > maxReplicationLogLines {
+			logLines = logLines[len(logLines)-maxReplicationLogLines:]
+		}
+	}
+
+	minioPolicy, convErr := h.toMinioPolicy(ctx, policy)
+	var result minio.ReplicationResult
+	runErr := convErr
+	if runErr == nil {
+		result, runErr = h.Replicator.Run(ctx, minioPolicy, logFn)
+	}
+
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.BytesCopied = result.BytesCopied
+	job.ObjectsCopied = result.ObjectsCopied
+	job.LogTail = strings.Join(logLines, "\n")
+
+	switch {
+	case runErr != nil:
+		job.Status = replicationJobStatusFailed
+		job.Error = runErr.Error()
+	case len(result.Errors) > 0:
+		job.Status = replicationJobStatusFailed
+		job.Error = fmt.Sprintf("%d object(s) failed to copy", len(result.Errors))
+	default:
+		job.Status = replicationJobStatusSucceeded
+	}
+
+	if err := h.DatabaseService.StoreReplicationJob(job); err != nil {
+		log.Error(err, "Failed to persist replication job result")
+	}
+
+	policy.LastRunAt = &finishedAt
+	if err := h.DatabaseService.StoreReplicationPolicy(policy); err != nil {
+		log.Error(err, "Failed to persist policy's last-run timestamp")
+	}
+}
+
+// toMinioPolicy resolves policy's credentials ref and translates it into the
+// minio.ReplicationPolicy the Replicator operates on.
+func (h *ReplicationHandler) toMinioPolicy(ctx context.Context, policy *database.ReplicationPolicy) (minio.ReplicationPolicy, error) {
+	if h.CredentialsResolver == nil {
+		return minio.ReplicationPolicy{}, fmt.Errorf("no credentials resolver configured for replication")
+	}
+
+	accessKeyID, secretAccessKey, err := h.CredentialsResolver.Resolve(ctx, policy.TargetCredentialsRef)
+	if err != nil {
+		return minio.ReplicationPolicy{}, fmt.Errorf("failed to resolve target credentials: %w", err)
+	}
+
+	return minio.ReplicationPolicy{
+		ID:                    policy.ID,
+		SourceBucket:          policy.SourceBucket,
+		TargetBucket:          policy.TargetBucket,
+		TargetEndpoint:        policy.TargetEndpoint,
+		TargetAccessKeyID:     accessKeyID,
+		TargetSecretAccessKey: secretAccessKey,
+		TargetUseSSL:          true,
+	}, nil
+}
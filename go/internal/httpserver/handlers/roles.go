@@ -1,23 +1,94 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/kagent-dev/kagent/go/api/v1alpha2"
+	authctx "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
 	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
-	"github.com/kagent-dev/kagent/go/pkg/database"
+	"github.com/kagent-dev/kagent/go/pkg/auth"
 	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"github.com/kagent-dev/kagent/go/pkg/database"
+	"github.com/kagent-dev/kagent/go/pkg/dlock"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// tenantLabel is the Agent label used to scope a tenant's resources; agents
+// without it are considered to belong to authctx.DefaultTenantID.
+const tenantLabel = "kagent.dev/tenant"
+
 // RolesHandler handles role-related requests
 type RolesHandler struct {
 	*Base
+	// PolicyEvaluator, when set, is consulted for mutating operations so
+	// policy can be changed (e.g. in OPA) without a kagent redeploy. Nil
+	// disables the check.
+	PolicyEvaluator authctx.PolicyEvaluator
+	// Locks serializes mutating role operations across kagent controller
+	// replicas. Nil falls back to no cross-replica serialization (the
+	// Kubernetes resource itself remains the source of truth either way).
+	Locks *dlock.Manager
 }
 
 // NewRolesHandler creates a new RolesHandler
-func NewRolesHandler(base *Base) *RolesHandler {
-	return &RolesHandler{Base: base}
+func NewRolesHandler(base *Base, policyEvaluator authctx.PolicyEvaluator, locks *dlock.Manager) *RolesHandler {
+	return &RolesHandler{Base: base, PolicyEvaluator: policyEvaluator, Locks: locks}
+}
+
+// lockRole acquires a distributed lock scoped to tenantID+roleName, if Locks
+// is configured, and returns a release func safe to defer unconditionally.
+// The returned context should replace r.Context() for the remainder of the
+// handler so a force-released lock aborts in-flight work.
+func (h *RolesHandler) lockRole(r *http.Request, tenantID, roleName string) (context.Context, func(), error) {
+	if h.Locks == nil {
+		return r.Context(), func() {}, nil
+	}
+
+	lease, err := h.Locks.Acquire(r.Context(), fmt.Sprintf("role/%s/%s", tenantID, roleName))
+	if err != nil {
+		return nil, func() {}, errors.New(errors.ErrConflict, "Role is locked by another operation", err).WithField("roleName", roleName)
+	}
+
+	release := func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := lease.Release(releaseCtx); err != nil {
+			ctrllog.FromContext(r.Context()).Error(err, "failed to release role lock", "resource", roleName)
+		}
+	}
+
+	return lease.Context(), release, nil
+}
+
+// checkPolicy consults PolicyEvaluator, if configured, for a mutating role
+// operation. A nil PolicyEvaluator allows the request, matching the repo's
+// convention of nil-checking optional dependencies rather than requiring them.
+func (h *RolesHandler) checkPolicy(r *http.Request, verb auth.Verb, roleName string) error {
+	if h.PolicyEvaluator == nil {
+		return nil
+	}
+
+	session, ok := auth.AuthSessionFrom(r.Context())
+	if !ok {
+		return errors.New(errors.ErrUnauthenticated, "No valid session found", nil)
+	}
+
+	resource := auth.Resource{Type: "Role", Name: roleName}
+	allowed, err := h.PolicyEvaluator.Evaluate(r.Context(), session.Principal(), verb, resource, "")
+	if err != nil {
+		return errors.New(errors.ErrNoPermission, "Policy evaluation failed", err).WithField("roleName", roleName)
+	}
+	if !allowed {
+		return errors.New(errors.ErrNoPermission, "Denied by policy", nil).WithField("roleName", roleName)
+	}
+
+	return nil
 }
 
 // CreateRoleRequest represents the request body for creating a role
@@ -34,11 +105,12 @@ type UpdateRoleRequest struct {
 // HandleListRoles handles GET /api/roles requests
 func (h *RolesHandler) HandleListRoles(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("roles-handler").WithValues("operation", "list")
+	tenantID := authctx.TenantIDFrom(r.Context())
 
-	roles, err := h.DatabaseService.ListRoles()
+	roles, err := h.DatabaseService.ListRolesForTenant(tenantID)
 	if err != nil {
 		log.Error(err, "Failed to list roles")
-		w.RespondWithError(errors.NewInternalServerError("Failed to list roles", err))
+		w.RespondWithError(errors.New(errors.ErrInternal, "Failed to list roles", err))
 		return
 	}
 
@@ -50,18 +122,19 @@ func (h *RolesHandler) HandleListRoles(w ErrorResponseWriter, r *http.Request) {
 // HandleGetRole handles GET /api/roles/{name} requests
 func (h *RolesHandler) HandleGetRole(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("roles-handler").WithValues("operation", "get")
+	tenantID := authctx.TenantIDFrom(r.Context())
 
 	roleName, err := GetPathParam(r, "name")
 	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Failed to get role name from path", err))
+		w.RespondWithError(errors.New(errors.ErrBadInput, "Failed to get role name from path", err))
 		return
 	}
 	log = log.WithValues("roleName", roleName)
 
-	role, err := h.DatabaseService.GetRole(roleName)
+	role, err := h.DatabaseService.GetRoleForTenant(tenantID, roleName)
 	if err != nil {
 		log.Error(err, "Failed to get role")
-		w.RespondWithError(errors.NewNotFoundError("Role not found", err))
+		w.RespondWithError(errors.New(errors.ErrNotFound, "Role not found", err).WithField("roleName", roleName))
 		return
 	}
 
@@ -73,33 +146,52 @@ func (h *RolesHandler) HandleGetRole(w ErrorResponseWriter, r *http.Request) {
 // HandleCreateRole handles POST /api/roles requests
 func (h *RolesHandler) HandleCreateRole(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("roles-handler").WithValues("operation", "create")
+	tenantID := authctx.TenantIDFrom(r.Context())
 
 	var req CreateRoleRequest
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.New(errors.ErrBadInput, "Invalid request body", err))
 		return
 	}
 
 	if req.Name == "" {
-		w.RespondWithError(errors.NewBadRequestError("Role name is required", nil))
+		w.RespondWithError(errors.New(errors.ErrValidationFailed, "Role name is required", nil))
+		return
+	}
+
+	if err := h.checkPolicy(r, auth.VerbCreate, req.Name); err != nil {
+		w.RespondWithError(err)
 		return
 	}
 
-	// Check if role already exists
-	existing, _ := h.DatabaseService.GetRole(req.Name)
+	lockCtx, unlock, err := h.lockRole(r, tenantID, req.Name)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
+	// Check if role already exists within this tenant
+	existing, _ := h.DatabaseService.GetRoleForTenant(tenantID, req.Name)
 	if existing != nil {
-		w.RespondWithError(errors.NewConflictError("Role '"+req.Name+"' already exists", nil))
+		w.RespondWithError(errors.New(errors.ErrAlreadyExists, "Role already exists", nil).WithField("roleName", req.Name))
+		return
+	}
+
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.New(errors.ErrConflict, "Role lock was lost before the role could be created", lockCtx.Err()).WithField("roleName", req.Name))
 		return
 	}
 
 	role := &database.Role{
+		TenantID:    tenantID,
 		Name:        req.Name,
 		Description: req.Description,
 	}
 
 	if err := h.DatabaseService.StoreRole(role); err != nil {
 		log.Error(err, "Failed to create role")
-		w.RespondWithError(errors.NewInternalServerError("Failed to create role", err))
+		w.RespondWithError(errors.New(errors.ErrInternal, "Failed to create role", err).WithField("roleName", role.Name))
 		return
 	}
 
@@ -111,25 +203,43 @@ func (h *RolesHandler) HandleCreateRole(w ErrorResponseWriter, r *http.Request)
 // HandleUpdateRole handles PUT /api/roles/{name} requests
 func (h *RolesHandler) HandleUpdateRole(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("roles-handler").WithValues("operation", "update")
+	tenantID := authctx.TenantIDFrom(r.Context())
 
 	roleName, err := GetPathParam(r, "name")
 	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Failed to get role name from path", err))
+		w.RespondWithError(errors.New(errors.ErrBadInput, "Failed to get role name from path", err))
 		return
 	}
 	log = log.WithValues("roleName", roleName)
 
 	// Check if role exists
-	existing, err := h.DatabaseService.GetRole(roleName)
+	existing, err := h.DatabaseService.GetRoleForTenant(tenantID, roleName)
 	if err != nil {
 		log.Error(err, "Failed to get role")
-		w.RespondWithError(errors.NewNotFoundError("Role not found", err))
+		w.RespondWithError(errors.New(errors.ErrNotFound, "Role not found", err).WithField("roleName", roleName))
+		return
+	}
+
+	if err := h.checkPolicy(r, auth.VerbUpdate, roleName); err != nil {
+		w.RespondWithError(err)
 		return
 	}
 
+	lockCtx, unlock, err := h.lockRole(r, tenantID, roleName)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
 	var req UpdateRoleRequest
 	if err := DecodeJSONBody(r, &req); err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Invalid request body", err))
+		w.RespondWithError(errors.New(errors.ErrBadInput, "Invalid request body", err))
+		return
+	}
+
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.New(errors.ErrConflict, "Role lock was lost before the update could be applied", lockCtx.Err()).WithField("roleName", roleName))
 		return
 	}
 
@@ -138,7 +248,7 @@ func (h *RolesHandler) HandleUpdateRole(w ErrorResponseWriter, r *http.Request)
 
 	if err := h.DatabaseService.StoreRole(existing); err != nil {
 		log.Error(err, "Failed to update role")
-		w.RespondWithError(errors.NewInternalServerError("Failed to update role", err))
+		w.RespondWithError(errors.New(errors.ErrInternal, "Failed to update role", err).WithField("roleName", roleName))
 		return
 	}
 
@@ -151,42 +261,58 @@ func (h *RolesHandler) HandleUpdateRole(w ErrorResponseWriter, r *http.Request)
 // It checks if the role is in use by any agents before allowing deletion
 func (h *RolesHandler) HandleDeleteRole(w ErrorResponseWriter, r *http.Request) {
 	log := ctrllog.FromContext(r.Context()).WithName("roles-handler").WithValues("operation", "delete")
+	tenantID := authctx.TenantIDFrom(r.Context())
 
 	roleName, err := GetPathParam(r, "name")
 	if err != nil {
-		w.RespondWithError(errors.NewBadRequestError("Failed to get role name from path", err))
+		w.RespondWithError(errors.New(errors.ErrBadInput, "Failed to get role name from path", err))
 		return
 	}
 	log = log.WithValues("roleName", roleName)
 
 	// Check if role exists
-	_, err = h.DatabaseService.GetRole(roleName)
+	_, err = h.DatabaseService.GetRoleForTenant(tenantID, roleName)
 	if err != nil {
 		log.Error(err, "Failed to get role")
-		w.RespondWithError(errors.NewNotFoundError("Role not found", err))
+		w.RespondWithError(errors.New(errors.ErrNotFound, "Role not found", err).WithField("roleName", roleName))
 		return
 	}
 
+	if err := h.checkPolicy(r, auth.VerbDelete, roleName); err != nil {
+		w.RespondWithError(err)
+		return
+	}
+
+	lockCtx, unlock, err := h.lockRole(r, tenantID, roleName)
+	if err != nil {
+		w.RespondWithError(err)
+		return
+	}
+	defer unlock()
+
 	// Check if any agents use this role
-	agentsUsingRole, err := h.getAgentsUsingRole(r, roleName)
+	agentsUsingRole, err := h.getAgentsUsingRole(r, tenantID, roleName)
 	if err != nil {
 		log.Error(err, "Failed to check agents using role")
-		w.RespondWithError(errors.NewInternalServerError("Failed to check role usage", err))
+		w.RespondWithError(errors.New(errors.ErrInternal, "Failed to check role usage", err).WithField("roleName", roleName))
 		return
 	}
 
 	if len(agentsUsingRole) > 0 {
 		log.Info("Cannot delete role - in use by agents", "agents", agentsUsingRole)
-		w.RespondWithError(errors.NewConflictError(
-			"Cannot delete role '"+roleName+"' - used by agents: "+formatAgentList(agentsUsingRole),
-			nil,
-		))
+		w.RespondWithError(errors.New(errors.ErrConflict, "Cannot delete role - used by agents: "+formatAgentList(agentsUsingRole), nil).
+			WithField("roleName", roleName))
 		return
 	}
 
-	if err := h.DatabaseService.DeleteRole(roleName); err != nil {
+	if lockCtx.Err() != nil {
+		w.RespondWithError(errors.New(errors.ErrConflict, "Role lock was lost before the role could be deleted", lockCtx.Err()).WithField("roleName", roleName))
+		return
+	}
+
+	if err := h.DatabaseService.DeleteRoleForTenant(tenantID, roleName); err != nil {
 		log.Error(err, "Failed to delete role")
-		w.RespondWithError(errors.NewInternalServerError("Failed to delete role", err))
+		w.RespondWithError(errors.New(errors.ErrInternal, "Failed to delete role", err).WithField("roleName", roleName))
 		return
 	}
 
@@ -195,13 +321,27 @@ func (h *RolesHandler) HandleDeleteRole(w ErrorResponseWriter, r *http.Request)
 	RespondWithJSON(w, http.StatusOK, data)
 }
 
-// getAgentsUsingRole returns a list of agent names (namespace/name) that use the given role
-func (h *RolesHandler) getAgentsUsingRole(r *http.Request, roleName string) ([]string, error) {
+// getAgentsUsingRole returns a list of agent names (namespace/name) that use the given
+// role, restricted to agents labeled for tenantID so cross-tenant reads are impossible.
+// For authctx.DefaultTenantID, agents with no tenant label are included as well.
+func (h *RolesHandler) getAgentsUsingRole(r *http.Request, tenantID, roleName string) ([]string, error) {
 	agentList := &v1alpha2.AgentList{}
-	if err := h.KubeClient.List(r.Context(), agentList); err != nil {
+	if err := h.KubeClient.List(r.Context(), agentList, client.MatchingLabels{tenantLabel: tenantID}); err != nil {
 		return nil, err
 	}
 
+	if tenantID == authctx.DefaultTenantID {
+		unlabeled, err := labels.NewRequirement(tenantLabel, selection.DoesNotExist, nil)
+		if err != nil {
+			return nil, err
+		}
+		unlabeledList := &v1alpha2.AgentList{}
+		if err := h.KubeClient.List(r.Context(), unlabeledList, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*unlabeled)}); err != nil {
+			return nil, err
+		}
+		agentList.Items = append(agentList.Items, unlabeledList.Items...)
+	}
+
 	var agentsUsingRole []string
 	for _, agent := range agentList.Items {
 		for _, allowedRole := range agent.Spec.AllowedRoles {
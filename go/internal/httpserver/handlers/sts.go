@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/golang-jwt/jwt/v5"
+	authctx "github.com/kagent-dev/kagent/go/internal/httpserver/auth"
+	"github.com/kagent-dev/kagent/go/internal/httpserver/errors"
+	"github.com/kagent-dev/kagent/go/pkg/client/api"
+	"github.com/kagent-dev/kagent/go/pkg/database"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Defaults NewSTSHandler falls back to when given a zero maxSessionDuration
+// or clockSkewTolerance. A minted credential lasts MaxSessionDuration unless
+// the token's duration_seconds claim asks for less.
+const (
+	defaultSTSSessionDuration = 1 * time.Hour
+	defaultClockSkewTolerance = 2 * time.Minute
+)
+
+// STSHandler implements an STS-compatible token exchange endpoint: callers
+// present an OIDC/JWT client grant and receive a short-lived kagent access
+// credential scoped to one of the stored database.Role records.
+type STSHandler struct {
+	*Base
+	// JWKS validates the presented token's signature against a configurable
+	// JWKS URL, supporting both RSA and ECDSA keys.
+	JWKS *authctx.JWKSCache
+	// MaxSessionDuration bounds how long a minted credential may be valid
+	// for, regardless of what the caller requests.
+	MaxSessionDuration time.Duration
+	// ClockSkewTolerance is the leeway allowed on the token's exp/nbf/iat
+	// claims to absorb clock drift between the issuer and kagent.
+	ClockSkewTolerance time.Duration
+}
+
+// NewSTSHandler creates a new STSHandler. A zero maxSessionDuration or
+// clockSkewTolerance falls back to sane defaults.
+func NewSTSHandler(base *Base, jwks *authctx.JWKSCache, maxSessionDuration, clockSkewTolerance time.Duration) *STSHandler {
+	if maxSessionDuration <= 0 {
+		maxSessionDuration = defaultSTSSessionDuration
+	}
+	if clockSkewTolerance <= 0 {
+		clockSkewTolerance = defaultClockSkewTolerance
+	}
+	return &STSHandler{
+		Base:               base,
+		JWKS:               jwks,
+		MaxSessionDuration: maxSessionDuration,
+		ClockSkewTolerance: clockSkewTolerance,
+	}
+}
+
+// AssumeRoleWithClientGrantsResponse is the credential bundle minted for a
+// successful token exchange.
+type AssumeRoleWithClientGrantsResponse struct {
+	AccessKeyID     string    `json:"accessKeyId"`
+	SecretAccessKey string    `json:"secretAccessKey"`
+	SessionToken    string    `json:"sessionToken"`
+	Expiration      time.Time `json:"expiration"`
+}
+
+// HandleSTS handles POST /api/sts?Action=AssumeRoleWithClientGrants&Token=<jwt>
+// requests. It is the only Action currently supported; unknown or missing
+// Action values are rejected.
+func (h *STSHandler) HandleSTS(w ErrorResponseWriter, r *http.Request) {
+	log := ctrllog.FromContext(r.Context()).WithName("sts-handler").WithValues("operation", "assume-role")
+
+	switch r.URL.Query().Get("Action") {
+	case "AssumeRoleWithClientGrants":
+		h.handleAssumeRoleWithClientGrants(w, r, log)
+	case "":
+		w.RespondWithError(errors.NewBadRequestError("Action query parameter is required", nil))
+	default:
+		w.RespondWithError(errors.NewBadRequestError("Unsupported Action '"+r.URL.Query().Get("Action")+"'", nil))
+	}
+}
+
+func (h *STSHandler) handleAssumeRoleWithClientGrants(w ErrorResponseWriter, r *http.Request, log logr.Logger) {
+	if h.JWKS == nil {
+		w.RespondWithError(errors.NewInternalServerError("JWKS not configured", nil))
+		return
+	}
+
+	tokenString := r.URL.Query().Get("Token")
+	if tokenString == "" {
+		w.RespondWithError(errors.NewBadRequestError("Token query parameter is required", nil))
+		return
+	}
+
+	claims := jwt.MapClaims{}
+	parser := jwt.NewParser(jwt.WithLeeway(h.ClockSkewTolerance), jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}))
+	token, err := parser.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, fmt.Errorf("token is missing a kid header")
+		}
+		return h.JWKS.Key(r.Context(), kid)
+	})
+	if err != nil || !token.Valid {
+		log.Error(err, "Failed to validate client grant token")
+		w.RespondWithError(errors.NewUnauthorizedError("Invalid or expired token", err))
+		return
+	}
+
+	roleName, _ := claims["role"].(string)
+	if roleName == "" {
+		w.RespondWithError(errors.NewBadRequestError("Token does not carry a 'role' claim", nil))
+		return
+	}
+	subject, _ := claims["sub"].(string)
+
+	tenantID := authctx.TenantIDFrom(r.Context())
+	log = log.WithValues("tenantId", tenantID, "roleName", roleName, "subject", subject)
+
+	role, err := h.DatabaseService.GetRoleForTenant(tenantID, roleName)
+	if err != nil {
+		log.Error(err, "Token role does not map to a stored role")
+		w.RespondWithError(errors.NewForbiddenError("Role '"+roleName+"' is not recognized", err))
+		return
+	}
+
+	duration := h.MaxSessionDuration
+	if requested, ok := claims["duration_seconds"].(float64); ok && requested > 0 {
+		if d := time.Duration(requested) * time.Second; d < duration {
+			duration = d
+		}
+	}
+
+	accessKeyID, err := randomToken(16)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to mint credentials", err))
+		return
+	}
+	secretAccessKey, err := randomToken(32)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to mint credentials", err))
+		return
+	}
+	sessionToken, err := randomToken(32)
+	if err != nil {
+		w.RespondWithError(errors.NewInternalServerError("Failed to mint credentials", err))
+		return
+	}
+
+	expiration := time.Now().Add(duration)
+	creds := &database.STSCredentials{
+		TenantID:        tenantID,
+		RoleName:        role.Name,
+		Subject:         subject,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+		Expiration:      expiration,
+	}
+
+	if err := h.DatabaseService.StoreSTSCredentials(creds); err != nil {
+		log.Error(err, "Failed to persist STS credentials")
+		w.RespondWithError(errors.NewInternalServerError("Failed to mint credentials", err))
+		return
+	}
+
+	log.Info("Successfully minted STS credentials")
+	data := api.NewResponse(AssumeRoleWithClientGrantsResponse{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+		Expiration:      expiration,
+	}, "Successfully assumed role", false)
+	RespondWithJSON(w, http.StatusOK, data)
+}
+
+// randomToken returns a cryptographically random hex string of n bytes.
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
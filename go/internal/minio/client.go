@@ -5,10 +5,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"github.com/minio/minio-go/v7/pkg/sse"
+	"github.com/minio/minio-go/v7/pkg/tags"
 )
 
 // Config holds MinIO connection configuration
@@ -24,6 +30,43 @@ type Client struct {
 	client *minio.Client
 }
 
+// ObjectStore is the subset of Client's surface that handlers depend on.
+// It lets a handler's MinIO field be swapped for a decorator like
+// EncryptedClient without widening the handler's own interface every time
+// Client grows a method only some callers need.
+type ObjectStore interface {
+	BucketExists(ctx context.Context, bucketName string) (bool, error)
+	ListBuckets(ctx context.Context) ([]string, error)
+	CreateBucket(ctx context.Context, bucketName string) error
+	DeleteBucket(ctx context.Context, bucketName string) error
+	EnableVersioning(ctx context.Context, bucketName string) error
+	SetBucketLifecycle(ctx context.Context, bucketName string, rule LifecycleRule) error
+	GetBucketLifecycle(ctx context.Context, bucketName string) (*LifecycleRule, error)
+	SetBucketEncryption(ctx context.Context, bucketName string, cfg EncryptionConfig) error
+
+	UploadFile(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, contentType string, serverSide encrypt.ServerSide) error
+	DeleteFile(ctx context.Context, bucketName, objectName string) error
+	GetObject(ctx context.Context, bucketName, objectName string) ([]byte, error)
+	PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte, contentType string) error
+	GetObjectStream(ctx context.Context, bucketName, objectName string, opts GetObjectStreamOptions) (io.ReadCloser, ObjectInfo, error)
+	StatObject(ctx context.Context, bucketName, objectName, versionID string) (ObjectInfo, error)
+	ListObjectsInfo(ctx context.Context, bucketName, prefix string) ([]ObjectInfo, error)
+
+	ListObjectVersions(ctx context.Context, bucketName, objectName string) ([]ObjectVersion, error)
+	RemoveObjectVersion(ctx context.Context, bucketName, objectName, versionID string) error
+
+	SetObjectTags(ctx context.Context, bucketName, objectName string, tagMap map[string]string) error
+	GetObjectTags(ctx context.Context, bucketName, objectName string) (map[string]string, error)
+
+	PresignPut(ctx context.Context, bucketName, objectName string, ttl time.Duration) (string, error)
+	PresignGet(ctx context.Context, bucketName, objectName string, ttl time.Duration, responseContentDisposition string) (string, error)
+}
+
+var (
+	_ ObjectStore = (*Client)(nil)
+	_ ObjectStore = (*EncryptedClient)(nil)
+)
+
 // NewClient creates a new MinIO client
 func NewClient(cfg *Config) (*Client, error) {
 	client, err := minio.New(cfg.Endpoint, &minio.Options{
@@ -79,10 +122,13 @@ func (c *Client) DeleteBucket(ctx context.Context, bucketName string) error {
 	return nil
 }
 
-// UploadFile uploads a file to a bucket
-func (c *Client) UploadFile(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, contentType string) error {
+// UploadFile uploads a file to a bucket. serverSide is optional (nil disables
+// per-request server-side encryption) and is typically derived from the
+// target index's encryption config via ServerSideEncryptionFor.
+func (c *Client) UploadFile(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, contentType string, serverSide encrypt.ServerSide) error {
 	_, err := c.client.PutObject(ctx, bucketName, objectName, reader, size, minio.PutObjectOptions{
-		ContentType: contentType,
+		ContentType:          contentType,
+		ServerSideEncryption: serverSide,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to upload file: %w", err)
@@ -120,6 +166,35 @@ func (c *Client) ListFiles(ctx context.Context, bucketName, prefix string) ([]st
 	return files, nil
 }
 
+// tenantObjectKey prefixes objectName so tenants sharing a bucket can never
+// collide with or enumerate each other's objects.
+func tenantObjectKey(tenantID, objectName string) string {
+	return "tenants/" + tenantID + "/" + objectName
+}
+
+// UploadFileForTenant uploads a file scoped to tenantID by prefixing
+// objectName with tenants/<tenantID>/. Use this instead of UploadFile for any
+// bucket shared across tenants.
+func (c *Client) UploadFileForTenant(ctx context.Context, bucketName, tenantID, objectName string, reader io.Reader, size int64, contentType string, serverSide encrypt.ServerSide) error {
+	return c.UploadFile(ctx, bucketName, tenantObjectKey(tenantID, objectName), reader, size, contentType, serverSide)
+}
+
+// ListFilesForTenant lists files under tenants/<tenantID>/<prefix> in bucketName,
+// returning object keys with the tenant prefix stripped back off.
+func (c *Client) ListFilesForTenant(ctx context.Context, bucketName, tenantID, prefix string) ([]string, error) {
+	files, err := c.ListFiles(ctx, bucketName, tenantObjectKey(tenantID, prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	tenantPrefix := tenantObjectKey(tenantID, "")
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		names = append(names, strings.TrimPrefix(f, tenantPrefix))
+	}
+	return names, nil
+}
+
 // BucketExists checks if a bucket exists
 func (c *Client) BucketExists(ctx context.Context, bucketName string) (bool, error) {
 	return c.client.BucketExists(ctx, bucketName)
@@ -155,6 +230,23 @@ func (c *Client) GetObject(ctx context.Context, bucketName, objectName string) (
 	return data, nil
 }
 
+// GetObjectVersion retrieves a specific version of an object's contents as
+// bytes. versionID is optional; when empty, the current version is used.
+func (c *Client) GetObjectVersion(ctx context.Context, bucketName, objectName, versionID string) ([]byte, error) {
+	obj, err := c.client.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{VersionID: versionID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return data, nil
+}
+
 // PutObjectBytes uploads bytes as an object
 func (c *Client) PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte, contentType string) error {
 	reader := io.NopCloser(io.NewSectionReader(bytes.NewReader(data), 0, int64(len(data))))
@@ -167,12 +259,322 @@ func (c *Client) PutObjectBytes(ctx context.Context, bucketName, objectName stri
 	return nil
 }
 
+// PresignPut generates a presigned URL that allows a client to upload an
+// object directly to the bucket via HTTP PUT, without the request passing
+// through this server. minio-go's PresignedPutObject only signs the
+// bucket/object/expiry, so content-type is not enforced server-side: a
+// client can PUT any Content-Type (or none) to the returned URL regardless
+// of what it declared when requesting it.
+func (c *Client) PresignPut(ctx context.Context, bucketName, objectName string, ttl time.Duration) (string, error) {
+	u, err := c.client.PresignedPutObject(ctx, bucketName, objectName, ttl)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	return u.String(), nil
+}
+
+// PresignGet generates a presigned URL that allows a client to download an
+// object directly from the bucket via HTTP GET. responseContentDisposition,
+// when non-empty, is echoed back by MinIO as the Content-Disposition header
+// on the signed response so browsers download with the original filename.
+func (c *Client) PresignGet(ctx context.Context, bucketName, objectName string, ttl time.Duration, responseContentDisposition string) (string, error) {
+	reqParams := make(url.Values)
+	if responseContentDisposition != "" {
+		reqParams.Set("response-content-disposition", responseContentDisposition)
+	}
+
+	u, err := c.client.PresignedGetObject(ctx, bucketName, objectName, ttl, reqParams)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign download: %w", err)
+	}
+
+	return u.String(), nil
+}
+
 // ObjectInfo represents information about an object
 type ObjectInfo struct {
 	Name         string
 	Size         int64
 	ContentType  string
 	LastModified time.Time
+	ETag         string
+	// Encrypted is true when the object carries EncryptedClient's envelope
+	// metadata. Plain Client never sets it.
+	Encrypted bool
+}
+
+// StatObject returns metadata for an object without downloading its body.
+// versionID is optional; when empty, the current version is used.
+func (c *Client) StatObject(ctx context.Context, bucketName, objectName, versionID string) (ObjectInfo, error) {
+	stat, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{VersionID: versionID})
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	return ObjectInfo{
+		Name:         stat.Key,
+		Size:         stat.Size,
+		ContentType:  stat.ContentType,
+		LastModified: stat.LastModified,
+		ETag:         stat.ETag,
+	}, nil
+}
+
+// GetObjectStreamOptions configures GetObjectStream.
+type GetObjectStreamOptions struct {
+	VersionID string
+	// HasRange, when true, restricts the returned stream to the inclusive
+	// byte range [RangeStart, RangeEnd].
+	HasRange   bool
+	RangeStart int64
+	RangeEnd   int64
+}
+
+// GetObjectStream opens a streaming reader for an object (or a byte range of
+// it), without buffering the payload into memory. The caller must Close the
+// returned reader.
+func (c *Client) GetObjectStream(ctx context.Context, bucketName, objectName string, opts GetObjectStreamOptions) (io.ReadCloser, ObjectInfo, error) {
+	minioOpts := minio.GetObjectOptions{VersionID: opts.VersionID}
+	if opts.HasRange {
+		if err := minioOpts.SetRange(opts.RangeStart, opts.RangeEnd); err != nil {
+			return nil, ObjectInfo{}, fmt.Errorf("invalid range: %w", err)
+		}
+	}
+
+	obj, err := c.client.GetObject(ctx, bucketName, objectName, minioOpts)
+	if err != nil {
+		return nil, ObjectInfo{}, fmt.Errorf("failed to get object: %w", err)
+	}
+
+	stat, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		return nil, ObjectInfo{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	info := ObjectInfo{
+		Name:         stat.Key,
+		Size:         stat.Size,
+		ContentType:  stat.ContentType,
+		LastModified: stat.LastModified,
+		ETag:         stat.ETag,
+	}
+
+	return obj, info, nil
+}
+
+// EnableVersioning turns on S3 bucket versioning so that every PutObject call
+// creates a new, independently retrievable version instead of overwriting the
+// previous one.
+func (c *Client) EnableVersioning(ctx context.Context, bucketName string) error {
+	if err := c.client.EnableVersioning(ctx, bucketName); err != nil {
+		return fmt.Errorf("failed to enable bucket versioning: %w", err)
+	}
+	return nil
+}
+
+// ObjectVersion represents a single version of an object in a versioned bucket.
+type ObjectVersion struct {
+	VersionID      string
+	Size           int64
+	LastModified   time.Time
+	IsLatest       bool
+	IsDeleteMarker bool
+}
+
+// ListObjectVersions lists all versions of the given object, most recent first.
+func (c *Client) ListObjectVersions(ctx context.Context, bucketName, objectName string) ([]ObjectVersion, error) {
+	var versions []ObjectVersion
+
+	objectsCh := c.client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+		Prefix:       objectName,
+		Recursive:    true,
+		WithVersions: true,
+	})
+
+	for object := range objectsCh {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list object versions: %w", object.Err)
+		}
+		if object.Key != objectName {
+			continue
+		}
+		versions = append(versions, ObjectVersion{
+			VersionID:      object.VersionID,
+			Size:           object.Size,
+			LastModified:   object.LastModified,
+			IsLatest:       object.IsLatest,
+			IsDeleteMarker: object.IsDeleteMarker,
+		})
+	}
+
+	return versions, nil
+}
+
+// RemoveObjectVersion permanently deletes a single version of an object,
+// leaving other versions (and the current object, if a different version) intact.
+func (c *Client) RemoveObjectVersion(ctx context.Context, bucketName, objectName, versionID string) error {
+	err := c.client.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{VersionID: versionID})
+	if err != nil {
+		return fmt.Errorf("failed to remove object version: %w", err)
+	}
+	return nil
+}
+
+// SetObjectTags replaces the full tag set on an object with the given key/value
+// pairs. Passing an empty map clears all tags.
+func (c *Client) SetObjectTags(ctx context.Context, bucketName, objectName string, tagMap map[string]string) error {
+	objTags, err := tags.NewTags(tagMap, true)
+	if err != nil {
+		return fmt.Errorf("invalid tags: %w", err)
+	}
+
+	if err := c.client.PutObjectTagging(ctx, bucketName, objectName, objTags, minio.PutObjectTaggingOptions{}); err != nil {
+		return fmt.Errorf("failed to set object tags: %w", err)
+	}
+
+	return nil
+}
+
+// GetObjectTags returns the tag set currently applied to an object.
+func (c *Client) GetObjectTags(ctx context.Context, bucketName, objectName string) (map[string]string, error) {
+	objTags, err := c.client.GetObjectTagging(ctx, bucketName, objectName, minio.GetObjectTaggingOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object tags: %w", err)
+	}
+
+	return objTags.ToMap(), nil
+}
+
+// LifecycleRule describes a single bucket lifecycle rule in terms a RAG index
+// cares about: expiring current objects, expiring old versions, and tiering to
+// a cheaper storage class after a number of days.
+type LifecycleRule struct {
+	ExpirationDays                  int
+	NoncurrentVersionExpirationDays int
+	TransitionDays                  int
+	TransitionStorageClass          string
+}
+
+// SetBucketLifecycle replaces a bucket's lifecycle configuration with a single
+// rule built from the given parameters. Fields left at zero are omitted from
+// the rule, so e.g. a rule with only ExpirationDays set will not transition
+// anything.
+func (c *Client) SetBucketLifecycle(ctx context.Context, bucketName string, rule LifecycleRule) error {
+	lcRule := lifecycle.Rule{
+		ID:     "default",
+		Status: "Enabled",
+		RuleFilter: lifecycle.Filter{
+			Prefix: "",
+		},
+	}
+
+	if rule.ExpirationDays > 0 {
+		lcRule.Expiration = lifecycle.Expiration{Days: lifecycle.ExpirationDays(rule.ExpirationDays)}
+	}
+	if rule.NoncurrentVersionExpirationDays > 0 {
+		lcRule.NoncurrentVersionExpiration = lifecycle.NoncurrentVersionExpiration{
+			NoncurrentDays: lifecycle.ExpirationDays(rule.NoncurrentVersionExpirationDays),
+		}
+	}
+	if rule.TransitionDays > 0 {
+		lcRule.Transition = lifecycle.Transition{
+			Days:         lifecycle.ExpirationDays(rule.TransitionDays),
+			StorageClass: rule.TransitionStorageClass,
+		}
+	}
+
+	cfg := lifecycle.NewConfiguration()
+	cfg.Rules = []lifecycle.Rule{lcRule}
+
+	if err := c.client.SetBucketLifecycle(ctx, bucketName, cfg); err != nil {
+		return fmt.Errorf("failed to set bucket lifecycle: %w", err)
+	}
+
+	return nil
+}
+
+// GetBucketLifecycle returns the bucket's current lifecycle rule, if any. It
+// returns the zero LifecycleRule if the bucket has no lifecycle configured.
+func (c *Client) GetBucketLifecycle(ctx context.Context, bucketName string) (*LifecycleRule, error) {
+	cfg, err := c.client.GetBucketLifecycle(ctx, bucketName)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchLifecycleConfiguration" {
+			return &LifecycleRule{}, nil
+		}
+		return nil, fmt.Errorf("failed to get bucket lifecycle: %w", err)
+	}
+	if cfg == nil || len(cfg.Rules) == 0 {
+		return &LifecycleRule{}, nil
+	}
+
+	lcRule := cfg.Rules[0]
+	rule := &LifecycleRule{
+		ExpirationDays:                  int(lcRule.Expiration.Days),
+		NoncurrentVersionExpirationDays: int(lcRule.NoncurrentVersionExpiration.NoncurrentDays),
+		TransitionDays:                  int(lcRule.Transition.Days),
+		TransitionStorageClass:          lcRule.Transition.StorageClass,
+	}
+
+	return rule, nil
+}
+
+// EncryptionConfig describes the server-side encryption applied to a RAG
+// index's bucket. Type is "" (no encryption), "SSE-S3", or "SSE-KMS"; KMSKeyID
+// is required for SSE-KMS.
+type EncryptionConfig struct {
+	Type     string
+	KMSKeyID string
+}
+
+// SetBucketEncryption configures the bucket's default server-side encryption.
+// An empty cfg.Type is a no-op, leaving the bucket unencrypted.
+func (c *Client) SetBucketEncryption(ctx context.Context, bucketName string, cfg EncryptionConfig) error {
+	var config *sse.Configuration
+	switch cfg.Type {
+	case "":
+		return nil
+	case "SSE-S3":
+		config = sse.NewConfigurationSSES3()
+	case "SSE-KMS":
+		if cfg.KMSKeyID == "" {
+			return fmt.Errorf("SSE-KMS encryption requires a kmsKeyId")
+		}
+		config = sse.NewConfigurationSSEKMS(cfg.KMSKeyID)
+	default:
+		return fmt.Errorf("unsupported encryption type %q", cfg.Type)
+	}
+
+	if err := c.client.SetBucketEncryption(ctx, bucketName, config); err != nil {
+		return fmt.Errorf("failed to set bucket encryption: %w", err)
+	}
+
+	return nil
+}
+
+// ServerSideEncryptionFor derives the per-request encryption to pass to
+// UploadFile from an index's EncryptionConfig. It returns (nil, nil) for an
+// unencrypted index, and a clear error if cfg can't be satisfied (e.g.
+// SSE-KMS without a key ID) rather than letting PutObject fail generically.
+func ServerSideEncryptionFor(cfg EncryptionConfig) (encrypt.ServerSide, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case "SSE-S3":
+		return encrypt.NewSSE(), nil
+	case "SSE-KMS":
+		if cfg.KMSKeyID == "" {
+			return nil, fmt.Errorf("index requires SSE-KMS encryption but no kmsKeyId is configured")
+		}
+		serverSide, err := encrypt.NewSSEKMS(cfg.KMSKeyID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot satisfy SSE-KMS policy for key %q: %w", cfg.KMSKeyID, err)
+		}
+		return serverSide, nil
+	default:
+		return nil, fmt.Errorf("unsupported encryption type %q", cfg.Type)
+	}
 }
 
 // ListObjectsInfo lists objects with their info
@@ -193,6 +595,7 @@ func (c *Client) ListObjectsInfo(ctx context.Context, bucketName, prefix string)
 			Size:         object.Size,
 			ContentType:  object.ContentType,
 			LastModified: object.LastModified,
+			ETag:         object.ETag,
 		})
 	}
 
@@ -0,0 +1,315 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+// Object user-metadata keys holding the envelope-encryption bookkeeping for
+// an object. MinIO stores these as the X-Amz-Meta-Kagent-* headers.
+const (
+	metaKeyKey   = "Kagent-Key"
+	metaNonceKey = "Kagent-Nonce"
+	metaAlgKey   = "Kagent-Alg"
+)
+
+// gcmTagSize is the AES-GCM authentication tag appended to every ciphertext,
+// so an encrypted object's stored Size is this many bytes larger than its
+// plaintext.
+const gcmTagSize = 16
+
+// EncryptedClient wraps Client with KES-style client-side envelope
+// encryption: every object is encrypted with a per-object data key before it
+// reaches the server, and the data key itself is wrapped by kms under
+// keyName and carried alongside the object as user-metadata. This is
+// independent of (and stacks with) the bucket-level server-side encryption
+// configured via SetBucketEncryption/ServerSideEncryptionFor.
+type EncryptedClient struct {
+	*Client
+	kms     KMSProvider
+	keyName string
+}
+
+// NewEncryptedClient creates an EncryptedClient that wraps every object's
+// data key under keyName via kms.
+func NewEncryptedClient(cfg *Config, kms KMSProvider, keyName string) (*EncryptedClient, error) {
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedClient{Client: client, kms: kms, keyName: keyName}, nil
+}
+
+// envelopeAAD binds ciphertext to the bucket and object it was encrypted
+// for, so a wrapped key and body copied onto a different object fail to
+// decrypt instead of silently decrypting under the wrong identity.
+func envelopeAAD(bucketName, objectName string) []byte {
+	return []byte(bucketName + "/" + objectName)
+}
+
+// encryptForObject generates a fresh data key, encrypts plaintext under it,
+// and returns the ciphertext alongside the user-metadata to store with it.
+func (c *EncryptedClient) encryptForObject(ctx context.Context, bucketName, objectName string, plaintext []byte) (ciphertext []byte, userMetadata map[string]string, err error) {
+	kmsContext := map[string]string{"bucket": bucketName, "object": objectName}
+
+	dataKey, wrappedKey, err := c.kms.GenerateDataKey(ctx, c.keyName, kmsContext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	defer zero(dataKey)
+
+	ciphertext, nonce, err := encryptGCM(dataKey, plaintext, envelopeAAD(bucketName, objectName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encrypt object: %w", err)
+	}
+
+	return ciphertext, map[string]string{
+		metaKeyKey:   base64.StdEncoding.EncodeToString(wrappedKey),
+		metaNonceKey: base64.StdEncoding.EncodeToString(nonce),
+		metaAlgKey:   envelopeAlgAES256GCM,
+	}, nil
+}
+
+// decryptObject unwraps and decrypts ciphertext using the envelope metadata
+// previously stored alongside it. Objects with no Kagent-Key metadata are
+// assumed to predate encryption and are returned unchanged.
+func (c *EncryptedClient) decryptObject(ctx context.Context, bucketName, objectName string, userMetadata map[string]string, ciphertext []byte) ([]byte, error) {
+	wrappedB64, ok := userMetadata[metaKeyKey]
+	if !ok {
+		return ciphertext, nil
+	}
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(wrappedB64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", metaKeyKey, err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(userMetadata[metaNonceKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", metaNonceKey, err)
+	}
+
+	dataKey, err := c.kms.DecryptKey(ctx, c.keyName, wrappedKey, map[string]string{"bucket": bucketName, "object": objectName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	defer zero(dataKey)
+
+	plaintext, err := decryptGCM(dataKey, nonce, ciphertext, envelopeAAD(bucketName, objectName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt object: %w", err)
+	}
+	return plaintext, nil
+}
+
+// UploadFile encrypts reader's contents with a fresh per-object data key
+// before uploading. serverSide, if set, is applied on top as additional
+// bucket-level server-side encryption.
+func (c *EncryptedClient) UploadFile(ctx context.Context, bucketName, objectName string, reader io.Reader, size int64, contentType string, serverSide encrypt.ServerSide) error {
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read object for encryption: %w", err)
+	}
+
+	return c.putEncrypted(ctx, bucketName, objectName, plaintext, contentType, serverSide)
+}
+
+// PutObjectBytes encrypts data with a fresh per-object data key before
+// uploading.
+func (c *EncryptedClient) PutObjectBytes(ctx context.Context, bucketName, objectName string, data []byte, contentType string) error {
+	return c.putEncrypted(ctx, bucketName, objectName, data, contentType, nil)
+}
+
+func (c *EncryptedClient) putEncrypted(ctx context.Context, bucketName, objectName string, plaintext []byte, contentType string, serverSide encrypt.ServerSide) error {
+	ciphertext, userMetadata, err := c.encryptForObject(ctx, bucketName, objectName, plaintext)
+	if err != nil {
+		return err
+	}
+
+	reader := io.NopCloser(bytes.NewReader(ciphertext))
+	_, err = c.client.PutObject(ctx, bucketName, objectName, reader, int64(len(ciphertext)), minio.PutObjectOptions{
+		ContentType:          contentType,
+		ServerSideEncryption: serverSide,
+		UserMetadata:         userMetadata,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload encrypted object: %w", err)
+	}
+
+	return nil
+}
+
+// GetObject downloads objectName and decrypts it using the data key wrapped
+// in its user-metadata.
+func (c *EncryptedClient) GetObject(ctx context.Context, bucketName, objectName string) ([]byte, error) {
+	stat, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	ciphertext, err := c.Client.GetObject(ctx, bucketName, objectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.decryptObject(ctx, bucketName, objectName, stat.UserMetadata, ciphertext)
+}
+
+// StatObject returns metadata the same way Client.StatObject does, flagging
+// envelope-encrypted objects and correcting their Size down to the plaintext
+// length, as ListObjectsInfo does.
+func (c *EncryptedClient) StatObject(ctx context.Context, bucketName, objectName, versionID string) (ObjectInfo, error) {
+	stat, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{VersionID: versionID})
+	if err != nil {
+		return ObjectInfo{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	info := ObjectInfo{Name: stat.Key, Size: stat.Size, ContentType: stat.ContentType, LastModified: stat.LastModified, ETag: stat.ETag}
+	if _, ok := stat.UserMetadata[metaKeyKey]; ok {
+		info.Encrypted = true
+		info.Size -= gcmTagSize
+	}
+	return info, nil
+}
+
+// GetObjectStream downloads and decrypts objectName in full, then returns a
+// reader over its plaintext (or, if opts.HasRange, the requested byte range
+// of it). Unlike Client.GetObjectStream this cannot stream the object
+// incrementally: the body is sealed as a single AES-GCM unit, so the whole
+// ciphertext must be read and authenticated before any plaintext byte is
+// available, and a Range request is served by slicing the decrypted result
+// rather than by restricting what's fetched from MinIO.
+func (c *EncryptedClient) GetObjectStream(ctx context.Context, bucketName, objectName string, opts GetObjectStreamOptions) (io.ReadCloser, ObjectInfo, error) {
+	stat, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{VersionID: opts.VersionID})
+	if err != nil {
+		return nil, ObjectInfo{}, fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	ciphertext, err := c.Client.GetObjectVersion(ctx, bucketName, objectName, opts.VersionID)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	plaintext, err := c.decryptObject(ctx, bucketName, objectName, stat.UserMetadata, ciphertext)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+
+	info := ObjectInfo{
+		Name:         stat.Key,
+		Size:         int64(len(plaintext)),
+		ContentType:  stat.ContentType,
+		LastModified: stat.LastModified,
+		ETag:         stat.ETag,
+	}
+
+	if opts.HasRange {
+		if opts.RangeStart < 0 || opts.RangeEnd >= int64(len(plaintext)) || opts.RangeStart > opts.RangeEnd {
+			return nil, ObjectInfo{}, fmt.Errorf("invalid range [%d,%d] for object of size %d", opts.RangeStart, opts.RangeEnd, len(plaintext))
+		}
+		plaintext = plaintext[opts.RangeStart : opts.RangeEnd+1]
+	}
+
+	return io.NopCloser(bytes.NewReader(plaintext)), info, nil
+}
+
+// ListObjectsInfo lists objects the same way Client.ListObjectsInfo does,
+// additionally flagging which objects are envelope-encrypted and correcting
+// their reported Size back down to the plaintext length (ciphertext is
+// gcmTagSize bytes larger than the plaintext it was sealed from).
+func (c *EncryptedClient) ListObjectsInfo(ctx context.Context, bucketName, prefix string) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+
+	objectsCh := c.client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
+		Prefix:       prefix,
+		Recursive:    true,
+		WithMetadata: true,
+	})
+
+	for object := range objectsCh {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+
+		info := ObjectInfo{
+			Name:         object.Key,
+			Size:         object.Size,
+			ContentType:  object.ContentType,
+			LastModified: object.LastModified,
+			ETag:         object.ETag,
+		}
+		if _, ok := object.UserMetadata[metaKeyKey]; ok {
+			info.Encrypted = true
+			info.Size -= gcmTagSize
+		}
+		objects = append(objects, info)
+	}
+
+	return objects, nil
+}
+
+// RotateKey re-wraps objectName's data key under the current state of keyName
+// without decrypting or rewriting the object body, so rotating (or
+// re-authorizing) a KMS master key doesn't require re-uploading every object
+// it protects. It requires a KMSProvider that also implements KeyWrapper.
+func (c *EncryptedClient) RotateKey(ctx context.Context, bucketName, objectName string) error {
+	wrapper, ok := c.kms.(KeyWrapper)
+	if !ok {
+		return fmt.Errorf("KMS provider %T does not support key rotation", c.kms)
+	}
+
+	stat, err := c.client.StatObject(ctx, bucketName, objectName, minio.StatObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to stat object: %w", err)
+	}
+
+	wrappedB64, ok := stat.UserMetadata[metaKeyKey]
+	if !ok {
+		return fmt.Errorf("object %s/%s is not envelope-encrypted", bucketName, objectName)
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(wrappedB64)
+	if err != nil {
+		return fmt.Errorf("invalid %s metadata: %w", metaKeyKey, err)
+	}
+
+	kmsContext := map[string]string{"bucket": bucketName, "object": objectName}
+
+	dataKey, err := c.kms.DecryptKey(ctx, c.keyName, wrappedKey, kmsContext)
+	if err != nil {
+		return fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	defer zero(dataKey)
+
+	rewrapped, err := wrapper.WrapKey(ctx, c.keyName, dataKey, kmsContext)
+	if err != nil {
+		return fmt.Errorf("failed to re-wrap data key: %w", err)
+	}
+
+	_, err = c.client.CopyObject(ctx,
+		minio.CopyDestOptions{
+			Bucket: bucketName,
+			Object: objectName,
+			UserMetadata: map[string]string{
+				metaKeyKey:   base64.StdEncoding.EncodeToString(rewrapped),
+				metaNonceKey: stat.UserMetadata[metaNonceKey],
+				metaAlgKey:   stat.UserMetadata[metaAlgKey],
+			},
+			ReplaceMetadata: true,
+		},
+		minio.CopySrcOptions{
+			Bucket: bucketName,
+			Object: objectName,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update object metadata: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,376 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// envelopeAlgAES256GCM is the only algorithm NewEncryptedClient currently
+// wraps data keys and object bodies with; it's recorded in object
+// user-metadata so a future algorithm change can still decrypt old objects.
+const envelopeAlgAES256GCM = "AES256-GCM"
+
+// KeyInfo describes a KMS master key, as returned by KMSProvider.DescribeKey.
+type KeyInfo struct {
+	KeyName   string `json:"name"`
+	Algorithm string `json:"algorithm"`
+}
+
+// KMSProvider generates and unwraps per-object data keys for envelope
+// encryption. keyName identifies the KMS master key (the "key-encryption
+// key"); kmsContext is authenticated (but not encrypted) associated data the
+// provider binds the wrapped key to, so a wrapped key can't be replayed
+// against a different object.
+type KMSProvider interface {
+	// GenerateDataKey mints a new random data key and returns both its
+	// plaintext (to use for this one encrypt operation, then discard) and
+	// its ciphertext wrapped under keyName (to store alongside the object).
+	GenerateDataKey(ctx context.Context, keyName string, kmsContext map[string]string) (plaintext, wrapped []byte, err error)
+	// DecryptKey unwraps a data key previously returned by GenerateDataKey or
+	// KeyWrapper.WrapKey. kmsContext must match what was passed when the key
+	// was wrapped.
+	DecryptKey(ctx context.Context, keyName string, wrapped []byte, kmsContext map[string]string) (plaintext []byte, err error)
+	// DescribeKey returns metadata about keyName, mainly so callers can
+	// confirm it exists before relying on it.
+	DescribeKey(ctx context.Context, keyName string) (KeyInfo, error)
+}
+
+// KeyWrapper is an optional KMSProvider capability for re-wrapping a data key
+// whose plaintext the caller already holds (from a prior DecryptKey), without
+// minting a new one. RotateKey needs this to rotate an object's wrapped data
+// key without touching its ciphertext body. Both MemKMS and KESClient
+// implement it.
+type KeyWrapper interface {
+	WrapKey(ctx context.Context, keyName string, plaintext []byte, kmsContext map[string]string) (wrapped []byte, err error)
+}
+
+// contextAAD deterministically serializes kmsContext into bytes suitable for
+// use as GCM additional data, so the same context always produces the same
+// AAD regardless of map iteration order.
+func contextAAD(kmsContext map[string]string) []byte {
+	keys := make([]string, 0, len(kmsContext))
+	for k := range kmsContext {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		buf.WriteString(kmsContext[k])
+		buf.WriteByte(';')
+	}
+	return buf.Bytes()
+}
+
+// encryptGCM seals plaintext under key, returning the ciphertext (with the
+// GCM authentication tag appended) and the random nonce used.
+func encryptGCM(key, plaintext, aad []byte) (ciphertext, nonce []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nil, nonce, plaintext, aad), nonce, nil
+}
+
+// decryptGCM opens ciphertext (produced by encryptGCM) under key.
+func decryptGCM(key, nonce, ciphertext, aad []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// zero overwrites a plaintext data key's backing array once it's no longer
+// needed, so it doesn't linger in memory for the life of the process.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// MemKMS is an in-process KMSProvider that keeps its master keys in memory.
+// It's meant for tests and local development, not production use: master
+// keys don't survive a restart and are never persisted.
+type MemKMS struct {
+	mu   sync.Mutex
+	keys map[string][]byte
+}
+
+// NewMemKMS creates an empty MemKMS. Master keys are generated lazily, the
+// first time a keyName is used.
+func NewMemKMS() *MemKMS {
+	return &MemKMS{keys: make(map[string][]byte)}
+}
+
+func (m *MemKMS) masterKey(keyName string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if key, ok := m.keys[keyName]; ok {
+		return key, nil
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate master key: %w", err)
+	}
+	m.keys[keyName] = key
+	return key, nil
+}
+
+func (m *MemKMS) GenerateDataKey(ctx context.Context, keyName string, kmsContext map[string]string) ([]byte, []byte, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrapped, err := m.WrapKey(ctx, keyName, dataKey, kmsContext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dataKey, wrapped, nil
+}
+
+func (m *MemKMS) WrapKey(ctx context.Context, keyName string, plaintext []byte, kmsContext map[string]string) ([]byte, error) {
+	master, err := m.masterKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, nonce, err := encryptGCM(master, plaintext, contextAAD(kmsContext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+
+	return append(nonce, ciphertext...), nil
+}
+
+func (m *MemKMS) DecryptKey(ctx context.Context, keyName string, wrapped []byte, kmsContext map[string]string) ([]byte, error) {
+	master, err := m.masterKey(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	const nonceSize = 12
+	if len(wrapped) < nonceSize {
+		return nil, fmt.Errorf("wrapped key is too short")
+	}
+	nonce, ciphertext := wrapped[:nonceSize], wrapped[nonceSize:]
+
+	plaintext, err := decryptGCM(master, nonce, ciphertext, contextAAD(kmsContext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (m *MemKMS) DescribeKey(ctx context.Context, keyName string) (KeyInfo, error) {
+	if _, err := m.masterKey(keyName); err != nil {
+		return KeyInfo{}, err
+	}
+	return KeyInfo{KeyName: keyName, Algorithm: envelopeAlgAES256GCM}, nil
+}
+
+var (
+	_ KMSProvider = (*MemKMS)(nil)
+	_ KeyWrapper  = (*MemKMS)(nil)
+)
+
+// KESClientConfig configures a KESClient's mTLS connection to a remote
+// KES-style key server.
+type KESClientConfig struct {
+	// Endpoint is the base URL of the key server, e.g. "https://kes.internal:7373".
+	Endpoint string
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// Timeout bounds each request to the key server. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// KESClient is a KMSProvider backed by a remote KES-style key server, reached
+// over mutually-authenticated TLS.
+type KESClient struct {
+	endpoint string
+	http     *http.Client
+}
+
+// NewKESClient creates a KESClient from cfg, loading the client certificate
+// and CA bundle used to establish mTLS with the key server.
+func NewKESClient(cfg KESClientConfig) (*KESClient, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	caCert, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate")
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &KESClient{
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+		http: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					Certificates: []tls.Certificate{cert},
+					RootCAs:      caPool,
+					MinVersion:   tls.VersionTLS12,
+				},
+			},
+		},
+	}, nil
+}
+
+func (k *KESClient) do(ctx context.Context, method, path string, body any) ([]byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode KES request: %w", err)
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, k.endpoint+path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build KES request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := k.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("KES request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KES response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("KES request to %s failed with status %d: %s", path, resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
+
+func (k *KESClient) GenerateDataKey(ctx context.Context, keyName string, kmsContext map[string]string) ([]byte, []byte, error) {
+	respBody, err := k.do(ctx, http.MethodPost, "/v1/key/generate/"+url.PathEscape(keyName), map[string]any{
+		"context": contextAAD(kmsContext),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out struct {
+		Plaintext  []byte `json:"plaintext"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode KES response: %w", err)
+	}
+	return out.Plaintext, out.Ciphertext, nil
+}
+
+func (k *KESClient) WrapKey(ctx context.Context, keyName string, plaintext []byte, kmsContext map[string]string) ([]byte, error) {
+	respBody, err := k.do(ctx, http.MethodPost, "/v1/key/wrap/"+url.PathEscape(keyName), map[string]any{
+		"plaintext": plaintext,
+		"context":   contextAAD(kmsContext),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode KES response: %w", err)
+	}
+	return out.Ciphertext, nil
+}
+
+func (k *KESClient) DecryptKey(ctx context.Context, keyName string, wrapped []byte, kmsContext map[string]string) ([]byte, error) {
+	respBody, err := k.do(ctx, http.MethodPost, "/v1/key/decrypt/"+url.PathEscape(keyName), map[string]any{
+		"ciphertext": wrapped,
+		"context":    contextAAD(kmsContext),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Plaintext []byte `json:"plaintext"`
+	}
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return nil, fmt.Errorf("failed to decode KES response: %w", err)
+	}
+	return out.Plaintext, nil
+}
+
+func (k *KESClient) DescribeKey(ctx context.Context, keyName string) (KeyInfo, error) {
+	respBody, err := k.do(ctx, http.MethodGet, "/v1/key/describe/"+url.PathEscape(keyName), nil)
+	if err != nil {
+		return KeyInfo{}, err
+	}
+
+	var out KeyInfo
+	if err := json.Unmarshal(respBody, &out); err != nil {
+		return KeyInfo{}, fmt.Errorf("failed to decode KES response: %w", err)
+	}
+	return out, nil
+}
+
+var (
+	_ KMSProvider = (*KESClient)(nil)
+	_ KeyWrapper  = (*KESClient)(nil)
+)
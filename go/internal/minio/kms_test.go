@@ -0,0 +1,125 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestEncryptDecryptGCMRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 32)
+	plaintext := []byte("the quick brown fox jumps over the lazy dog")
+	aad := []byte("bucket/object")
+
+	ciphertext, nonce, err := encryptGCM(key, plaintext, aad)
+	if err != nil {
+		t.Fatalf("encryptGCM: %v", err)
+	}
+	if bytes.Equal(ciphertext, plaintext) {
+		t.Fatal("ciphertext must not equal plaintext")
+	}
+
+	decrypted, err := decryptGCM(key, nonce, ciphertext, aad)
+	if err != nil {
+		t.Fatalf("decryptGCM: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted = %q, want %q", decrypted, plaintext)
+	}
+}
+
+func TestDecryptGCMRejectsWrongAAD(t *testing.T) {
+	key := bytes.Repeat([]byte{0x7, 0x9}, 16)
+	plaintext := []byte("envelope payload")
+
+	ciphertext, nonce, err := encryptGCM(key, plaintext, []byte("bucketA/objectA"))
+	if err != nil {
+		t.Fatalf("encryptGCM: %v", err)
+	}
+
+	if _, err := decryptGCM(key, nonce, ciphertext, []byte("bucketB/objectB")); err == nil {
+		t.Fatal("expected decryptGCM to reject ciphertext bound to a different AAD")
+	}
+}
+
+func TestDecryptGCMRejectsTamperedCiphertext(t *testing.T) {
+	key := bytes.Repeat([]byte{0x11}, 32)
+	plaintext := []byte("envelope payload")
+	aad := []byte("bucket/object")
+
+	ciphertext, nonce, err := encryptGCM(key, plaintext, aad)
+	if err != nil {
+		t.Fatalf("encryptGCM: %v", err)
+	}
+	ciphertext[0] ^= 0xFF
+
+	if _, err := decryptGCM(key, nonce, ciphertext, aad); err == nil {
+		t.Fatal("expected decryptGCM to reject tampered ciphertext")
+	}
+}
+
+func TestMemKMSGenerateAndDecryptDataKeyRoundTrip(t *testing.T) {
+	kms := NewMemKMS()
+	ctx := context.Background()
+	kmsContext := map[string]string{"bucket": "b1", "object": "o1"}
+
+	dataKey, wrapped, err := kms.GenerateDataKey(ctx, "key-1", kmsContext)
+	if err != nil {
+		t.Fatalf("GenerateDataKey: %v", err)
+	}
+	if len(dataKey) != 32 {
+		t.Fatalf("expected a 32-byte data key, got %d bytes", len(dataKey))
+	}
+	if bytes.Equal(wrapped, dataKey) {
+		t.Fatal("wrapped key must not equal the plaintext data key")
+	}
+
+	unwrapped, err := kms.DecryptKey(ctx, "key-1", wrapped, kmsContext)
+	if err != nil {
+		t.Fatalf("DecryptKey: %v", err)
+	}
+	if !bytes.Equal(unwrapped, dataKey) {
+		t.Fatal("DecryptKey did not return the original data key")
+	}
+}
+
+func TestMemKMSDecryptKeyRejectsWrongContext(t *testing.T) {
+	kms := NewMemKMS()
+	ctx := context.Background()
+
+	_, wrapped, err := kms.GenerateDataKey(ctx, "key-1", map[string]string{"bucket": "b1", "object": "o1"})
+	if err != nil {
+		t.Fatalf("GenerateDataKey: %v", err)
+	}
+
+	if _, err := kms.DecryptKey(ctx, "key-1", wrapped, map[string]string{"bucket": "b2", "object": "o2"}); err == nil {
+		t.Fatal("expected DecryptKey to reject a wrapped key unwrapped under a different context")
+	}
+}
+
+func TestMemKMSWrapKeyAllowsRotationWithoutReencryptingBody(t *testing.T) {
+	kms := NewMemKMS()
+	ctx := context.Background()
+	kmsContext := map[string]string{"bucket": "b1", "object": "o1"}
+
+	dataKey, originalWrapped, err := kms.GenerateDataKey(ctx, "key-1", kmsContext)
+	if err != nil {
+		t.Fatalf("GenerateDataKey: %v", err)
+	}
+
+	rewrapped, err := kms.WrapKey(ctx, "key-1", dataKey, kmsContext)
+	if err != nil {
+		t.Fatalf("WrapKey: %v", err)
+	}
+	if bytes.Equal(rewrapped, originalWrapped) {
+		t.Fatal("re-wrapping should produce a fresh ciphertext (fresh nonce), not reuse the original")
+	}
+
+	unwrapped, err := kms.DecryptKey(ctx, "key-1", rewrapped, kmsContext)
+	if err != nil {
+		t.Fatalf("DecryptKey on re-wrapped key: %v", err)
+	}
+	if !bytes.Equal(unwrapped, dataKey) {
+		t.Fatal("DecryptKey on re-wrapped key did not return the original data key")
+	}
+}
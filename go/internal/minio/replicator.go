@@ -0,0 +1,184 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ReplicationPolicy describes one source-to-target bucket replication job:
+// where objects come from, where they go, and how much concurrency/retry to
+// allow while copying them. It's the in-memory counterpart of
+// database.ReplicationPolicy; the HTTP handler layer is responsible for
+// translating between the two.
+type ReplicationPolicy struct {
+	ID           string
+	SourceBucket string
+	TargetBucket string
+
+	TargetEndpoint        string
+	TargetAccessKeyID     string
+	TargetSecretAccessKey string
+	TargetUseSSL          bool
+
+	// Concurrency bounds how many objects are copied at once. Defaults to 4
+	// when <= 0.
+	Concurrency int
+	// MaxRetries bounds per-object retry attempts on copy failure. Defaults
+	// to 3 when <= 0.
+	MaxRetries int
+}
+
+func (p ReplicationPolicy) withDefaults() ReplicationPolicy {
+	if p.Concurrency <= 0 {
+		p.Concurrency = 4
+	}
+	if p.MaxRetries <= 0 {
+		p.MaxRetries = 3
+	}
+	return p
+}
+
+// ReplicationResult summarizes one run of a ReplicationPolicy.
+type ReplicationResult struct {
+	ObjectsCopied int
+	BytesCopied   int64
+	// Errors holds one error per object that failed to copy after
+	// exhausting retries; Run still copies everything else it can.
+	Errors []error
+}
+
+// Replicator copies objects missing or stale in a target bucket from a
+// source Client, driven by ReplicationPolicy records. It only implements the
+// diff-and-copy primitive; scheduling (cron) and persistence of policies and
+// job history live in the httpserver handlers layer, which calls Run.
+type Replicator struct {
+	source *Client
+}
+
+// NewReplicator creates a Replicator that copies from source.
+func NewReplicator(source *Client) *Replicator {
+	return &Replicator{source: source}
+}
+
+// objectNeedsCopy reports whether target is missing src, or present but
+// stale (older LastModified or a different Size).
+func objectNeedsCopy(src ObjectInfo, target map[string]ObjectInfo) bool {
+	tgt, ok := target[src.Name]
+	if !ok {
+		return true
+	}
+	return src.LastModified.After(tgt.LastModified) || src.Size != tgt.Size
+}
+
+// LogFunc receives a line of progress output from Run, for callers that want
+// to persist a log tail alongside a job record.
+type LogFunc func(format string, args ...any)
+
+// Run diffs policy.SourceBucket against policy.TargetBucket on a freshly
+// created target client, then copies every missing or newer object across,
+// up to policy.Concurrency at a time, retrying each object up to
+// policy.MaxRetries times before giving up on it. It does not stop early on
+// a per-object failure; the caller decides what to do with the returned
+// errors.
+func (r *Replicator) Run(ctx context.Context, policy ReplicationPolicy, log LogFunc) (ReplicationResult, error) {
+	policy = policy.withDefaults()
+	if log == nil {
+		log = func(string, ...any) {}
+	}
+
+	target, err := NewClient(&Config{
+		Endpoint:        policy.TargetEndpoint,
+		AccessKeyID:     policy.TargetAccessKeyID,
+		SecretAccessKey: policy.TargetSecretAccessKey,
+		UseSSL:          policy.TargetUseSSL,
+	})
+	if err != nil {
+		return ReplicationResult{}, fmt.Errorf("failed to create target client: %w", err)
+	}
+
+	sourceObjects, err := r.source.ListObjectsInfo(ctx, policy.SourceBucket, "")
+	if err != nil {
+		return ReplicationResult{}, fmt.Errorf("failed to list source objects: %w", err)
+	}
+
+	targetObjects, err := target.ListObjectsInfo(ctx, policy.TargetBucket, "")
+	if err != nil {
+		return ReplicationResult{}, fmt.Errorf("failed to list target objects: %w", err)
+	}
+	targetByName := make(map[string]ObjectInfo, len(targetObjects))
+	for _, obj := range targetObjects {
+		targetByName[obj.Name] = obj
+	}
+
+	var pending []ObjectInfo
+	for _, obj := range sourceObjects {
+		if objectNeedsCopy(obj, targetByName) {
+			pending = append(pending, obj)
+		}
+	}
+	log("replication plan: %d of %d source objects need copying", len(pending), len(sourceObjects))
+
+	sem := make(chan struct{}, policy.Concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	result := ReplicationResult{}
+
+	for _, obj := range pending {
+		obj := obj
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			size, err := r.copyObjectWithRetry(ctx, target, policy, obj)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log("failed to copy %s: %v", obj.Name, err)
+				result.Errors = append(result.Errors, fmt.Errorf("%s: %w", obj.Name, err))
+				return
+			}
+			result.ObjectsCopied++
+			result.BytesCopied += size
+			log("copied %s (%d bytes)", obj.Name, size)
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// copyObjectWithRetry copies a single object from the source bucket to
+// target, retrying transient failures up to policy.MaxRetries times with a
+// short linear backoff between attempts.
+func (r *Replicator) copyObjectWithRetry(ctx context.Context, target *Client, policy ReplicationPolicy, obj ObjectInfo) (int64, error) {
+	var lastErr error
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+
+		data, err := r.source.GetObject(ctx, policy.SourceBucket, obj.Name)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to read source object: %w", err)
+			continue
+		}
+
+		if err := target.PutObjectBytes(ctx, policy.TargetBucket, obj.Name, data, obj.ContentType); err != nil {
+			lastErr = fmt.Errorf("failed to write target object: %w", err)
+			continue
+		}
+
+		return int64(len(data)), nil
+	}
+
+	return 0, lastErr
+}
@@ -0,0 +1,190 @@
+package ragevents
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Config holds the server-wide defaults a Dispatcher needs to reach "nats"
+// and "kafka" sinks. Per-index routing (which sink, which events) comes from
+// the index's own NotificationTargets.
+type Config struct {
+	NATSURL      string
+	KafkaBrokers []string
+
+	// QueueSize bounds the number of pending deliveries held in memory.
+	// Defaults to 1024 if zero.
+	QueueSize int
+	// MaxRetries bounds redelivery attempts before an event is dropped.
+	// Defaults to 5 if zero.
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry; each subsequent retry
+	// doubles it. Defaults to 1s if zero.
+	BaseBackoff time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.QueueSize == 0 {
+		c.QueueSize = 1024
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = 5
+	}
+	if c.BaseBackoff == 0 {
+		c.BaseBackoff = time.Second
+	}
+	return c
+}
+
+type delivery struct {
+	target  NotificationTarget
+	event   Event
+	attempt int
+}
+
+// Dispatcher is a background worker that delivers Events to the
+// NotificationTargets configured on a RAG index, retrying failed deliveries
+// with exponential backoff. Publishers are created lazily and cached per
+// (type, target) pair.
+type Dispatcher struct {
+	cfg    Config
+	log    logr.Logger
+	queue  chan delivery
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	publishers map[string]EventPublisher
+}
+
+// NewDispatcher creates a Dispatcher. Call Start to begin processing events.
+func NewDispatcher(cfg Config, log logr.Logger) *Dispatcher {
+	cfg = cfg.withDefaults()
+	return &Dispatcher{
+		cfg:        cfg,
+		log:        log.WithName("ragevents"),
+		queue:      make(chan delivery, cfg.QueueSize),
+		publishers: make(map[string]EventPublisher),
+	}
+}
+
+// Start begins the background delivery worker. It stops when ctx is done.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+	go d.run(ctx)
+}
+
+// Stop shuts down the background worker and closes all cached publishers.
+func (d *Dispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, pub := range d.publishers {
+		if err := pub.Close(); err != nil {
+			d.log.Error(err, "failed to close event publisher", "publisher", key)
+		}
+	}
+}
+
+// Dispatch enqueues event for delivery to every target that subscribes to its
+// type. It never blocks the caller: a full queue drops the event and logs it.
+func (d *Dispatcher) Dispatch(targets []NotificationTarget, event Event) {
+	for _, target := range targets {
+		if !target.wants(event.Type) {
+			continue
+		}
+
+		item := delivery{target: target, event: event}
+		select {
+		case d.queue <- item:
+		default:
+			d.log.Info("event queue full, dropping event",
+				"idempotencyKey", event.IdempotencyKey(), "target", target.Target)
+		}
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-d.queue:
+			d.deliver(ctx, item)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, item delivery) {
+	pub, err := d.publisherFor(item.target)
+	if err != nil {
+		d.log.Error(err, "failed to create event publisher",
+			"type", item.target.Type, "target", item.target.Target)
+		return
+	}
+
+	if err := pub.Publish(ctx, item.event); err != nil {
+		d.retry(item, err)
+		return
+	}
+}
+
+func (d *Dispatcher) retry(item delivery, cause error) {
+	item.attempt++
+	if item.attempt > d.cfg.MaxRetries {
+		d.log.Error(cause, "dropping event after exhausting retries",
+			"idempotencyKey", item.event.IdempotencyKey(), "attempts", item.attempt)
+		return
+	}
+
+	backoff := d.cfg.BaseBackoff * time.Duration(1<<uint(item.attempt-1))
+	d.log.Info("retrying event delivery", "idempotencyKey", item.event.IdempotencyKey(),
+		"attempt", item.attempt, "backoff", backoff, "error", cause.Error())
+
+	time.AfterFunc(backoff, func() {
+		select {
+		case d.queue <- item:
+		default:
+			d.log.Info("event queue full, dropping retried event",
+				"idempotencyKey", item.event.IdempotencyKey())
+		}
+	})
+}
+
+func (d *Dispatcher) publisherFor(target NotificationTarget) (EventPublisher, error) {
+	key := target.Type + "|" + target.Target
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if pub, ok := d.publishers[key]; ok {
+		return pub, nil
+	}
+
+	pub, err := d.newPublisher(target)
+	if err != nil {
+		return nil, err
+	}
+	d.publishers[key] = pub
+	return pub, nil
+}
+
+func (d *Dispatcher) newPublisher(target NotificationTarget) (EventPublisher, error) {
+	switch target.Type {
+	case "webhook":
+		return newWebhookPublisher(target.Target)
+	case "nats":
+		return newNATSPublisher(d.cfg.NATSURL, target.Target)
+	case "kafka":
+		return newKafkaPublisher(d.cfg.KafkaBrokers, target.Target)
+	default:
+		return nil, fmt.Errorf("unsupported notification type %q", target.Type)
+	}
+}
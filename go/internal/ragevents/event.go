@@ -0,0 +1,34 @@
+package ragevents
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType identifies the kind of change an Event represents.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventDeleted EventType = "deleted"
+)
+
+// Event describes a single document change in a RAG index, published to
+// whatever sinks the index's NotificationTargets configure. Chunking and
+// embedding services subscribe to these instead of polling HandleListDocuments.
+type Event struct {
+	Type        EventType `json:"type"`
+	Index       string    `json:"index"`
+	Filename    string    `json:"filename"`
+	Size        int64     `json:"size,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	ETag        string    `json:"etag,omitempty"`
+	UploadedBy  string    `json:"uploadedBy,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// IdempotencyKey returns a stable key derived from the index, filename, and
+// ETag so a subscriber can dedupe deliveries retried after a transient failure.
+func (e Event) IdempotencyKey() string {
+	return fmt.Sprintf("%s/%s@%s", e.Index, e.Filename, e.ETag)
+}
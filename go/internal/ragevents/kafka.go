@@ -0,0 +1,49 @@
+package ragevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaPublisher delivers events by writing to a Kafka topic, keyed by the
+// event's idempotency key so compacted topics dedupe retried deliveries.
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func newKafkaPublisher(brokers []string, topic string) (*kafkaPublisher, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("no Kafka brokers configured")
+	}
+
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.LeastBytes{},
+	}
+
+	return &kafkaPublisher{writer: writer}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := kafka.Message{Key: []byte(event.IdempotencyKey()), Value: data}
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish to Kafka topic %s: %w", p.writer.Topic, err)
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
+
+var _ EventPublisher = (*kafkaPublisher)(nil)
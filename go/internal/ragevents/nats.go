@@ -0,0 +1,44 @@
+package ragevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsPublisher delivers events by publishing to a NATS subject.
+type natsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSPublisher(serverURL, subject string) (*natsPublisher, error) {
+	conn, err := nats.Connect(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS at %s: %w", serverURL, err)
+	}
+
+	return &natsPublisher{conn: conn, subject: subject}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := p.conn.Publish(p.subject, data); err != nil {
+		return fmt.Errorf("failed to publish to NATS subject %s: %w", p.subject, err)
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+var _ EventPublisher = (*natsPublisher)(nil)
@@ -0,0 +1,37 @@
+package ragevents
+
+import "context"
+
+// NotificationTarget configures a single sink that index events are
+// published to. It is persisted inside a RAG index's .metadata.json.
+type NotificationTarget struct {
+	// Type selects the sink implementation: "webhook", "nats", or "kafka".
+	Type string `json:"type"`
+	// Target is sink-specific: a webhook URL, a NATS subject, or a Kafka topic.
+	// The underlying connection (NATS server URL, Kafka brokers) comes from
+	// the server's Config, not from here.
+	Target string `json:"target"`
+	// Events restricts delivery to the listed event types. An empty list
+	// means "all events".
+	Events []EventType `json:"events,omitempty"`
+}
+
+// wants reports whether this target should receive events of type et.
+func (t NotificationTarget) wants(et EventType) bool {
+	if len(t.Events) == 0 {
+		return true
+	}
+	for _, want := range t.Events {
+		if want == et {
+			return true
+		}
+	}
+	return false
+}
+
+// EventPublisher delivers a single Event to a sink. Implementations must be
+// safe for concurrent use.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+	Close() error
+}
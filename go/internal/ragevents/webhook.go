@@ -0,0 +1,115 @@
+package ragevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// webhookPublisher delivers events as an HTTP POST of the JSON-encoded event.
+type webhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookPublisher(rawURL string) (*webhookPublisher, error) {
+	if err := validateWebhookURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	return &webhookPublisher{
+		url:    rawURL,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// validateWebhookURL rejects webhook targets that could be used to reach
+// internal-only services: the target is caller-supplied (CreateRAGIndexRequest
+// .Notifications), so without this check any caller could point the
+// server's outbound webhook POST at loopback, link-local, or other
+// private-network addresses (e.g. a cloud metadata service at
+// 169.254.169.254) simply by configuring a RAG index and uploading a
+// document.
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("webhook url must use http or https, got %q", parsed.Scheme)
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("webhook url must have a host")
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		if err := rejectPrivateIP(ip); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook host %q: %w", host, err)
+	}
+	for _, ip := range ips {
+		if err := rejectPrivateIP(ip); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rejectPrivateIP returns an error if ip is loopback, link-local (including
+// the 169.254.169.254 cloud metadata address), or otherwise confined to a
+// private/internal range that a public webhook target has no business
+// resolving to.
+func rejectPrivateIP(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() {
+		return fmt.Errorf("webhook url resolves to a disallowed address: %s", ip)
+	}
+	return nil
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", event.IdempotencyKey())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s returned status %d", p.url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *webhookPublisher) Close() error {
+	return nil
+}
+
+var _ EventPublisher = (*webhookPublisher)(nil)
@@ -0,0 +1,252 @@
+// Package dlock provides a distributed lock manager so mutating operations
+// that must be serialized across kagent controller replicas (not just within
+// one process) can take a lease-backed lock instead of a local sync.Mutex.
+package dlock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Backend is the storage engine a Manager uses to acquire, refresh, and
+// release distributed locks. Implementations must make Acquire atomic across
+// all kagent controller replicas (e.g. a Redis SET NX PX, or a Postgres
+// session-level advisory lock keyed by hashing the resource name).
+type Backend interface {
+	// Acquire attempts to take the lock for resource, returning a lease ID
+	// identifying this holder's lease on success. ErrLocked is returned if
+	// another holder already has it.
+	Acquire(ctx context.Context, resource string, ttl time.Duration) (leaseID string, err error)
+	// Refresh extends an already-held lease. It must fail if leaseID no
+	// longer owns the lock (e.g. the lease expired and someone else
+	// acquired it in the meantime).
+	Refresh(ctx context.Context, resource, leaseID string, ttl time.Duration) error
+	// Release gives up a held lease. Implementations should make this
+	// conditional on leaseID so a stale caller can't release someone else's
+	// lock.
+	Release(ctx context.Context, resource, leaseID string) error
+}
+
+// ErrLocked is returned by Backend.Acquire when resource is already locked.
+var ErrLocked = fmt.Errorf("dlock: resource is already locked")
+
+// Config controls lease TTL, refresh cadence, and the stale-lock threshold.
+type Config struct {
+	// TTL is how long a lease lasts before it must be refreshed.
+	TTL time.Duration
+	// RefreshInterval is how often the background goroutine renews the
+	// lease. Should be comfortably shorter than TTL (e.g. TTL/3).
+	RefreshInterval time.Duration
+	// MaxRefreshFailures is how many consecutive refresh failures are
+	// tolerated before the lease is force-released, both remotely
+	// (best-effort) and locally -- the "stale local lock" failure mode
+	// fixed upstream in MinIO's dsync package, where a lost remote lease
+	// left a local caller blocked forever.
+	MaxRefreshFailures int
+}
+
+func (c Config) withDefaults() Config {
+	if c.TTL == 0 {
+		c.TTL = 30 * time.Second
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = c.TTL / 3
+	}
+	if c.MaxRefreshFailures == 0 {
+		c.MaxRefreshFailures = 3
+	}
+	return c
+}
+
+// heldLock is the Manager's bookkeeping entry for TopLocks.
+type heldLock struct {
+	resource   string
+	leaseID    string
+	acquiredAt time.Time
+	expiresAt  time.Time
+}
+
+// LockInfo is a point-in-time snapshot of a held lock, for the TopLocks debug
+// endpoint.
+type LockInfo struct {
+	Resource   string    `json:"resource"`
+	LeaseID    string    `json:"leaseId"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// Manager acquires distributed locks from a Backend and keeps them alive with
+// a background refresh goroutine for as long as the returned Lease is held.
+type Manager struct {
+	backend Backend
+	cfg     Config
+	log     logr.Logger
+
+	mu    sync.Mutex
+	locks map[string]*heldLock
+}
+
+// NewManager creates a Manager backed by backend.
+func NewManager(backend Backend, cfg Config, log logr.Logger) *Manager {
+	return &Manager{
+		backend: backend,
+		cfg:     cfg.withDefaults(),
+		log:     log.WithName("dlock"),
+		locks:   make(map[string]*heldLock),
+	}
+}
+
+// Lease represents a held lock. Callers must call Release when done. The
+// lease's Done channel closes early if the background refresh loop gives up,
+// so long-running work should select on it and abort.
+type Lease struct {
+	manager  *Manager
+	resource string
+	leaseID  string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+// Done returns a channel that closes when the lease is released, either by
+// the caller or because the background refresh loop exhausted its retries.
+// In-flight work holding the lease must select on this and abort promptly.
+func (l *Lease) Done() <-chan struct{} {
+	return l.ctx.Done()
+}
+
+// Context returns a context derived from the one passed to Acquire, which is
+// cancelled early if the lease is force-released. Handlers should thread this
+// through their remaining work instead of the original request context.
+func (l *Lease) Context() context.Context {
+	return l.ctx
+}
+
+// Acquire takes the lock for resource. It makes a single attempt against the
+// backend (it does not retry/poll on ErrLocked) and, on success, starts a
+// background goroutine that refreshes the lease every cfg.RefreshInterval
+// until Release is called or MaxRefreshFailures consecutive refreshes fail.
+func (m *Manager) Acquire(ctx context.Context, resource string) (*Lease, error) {
+	leaseID, err := m.backend.Acquire(ctx, resource, m.cfg.TTL)
+	if err != nil {
+		return nil, err
+	}
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	lease := &Lease{
+		manager:  m,
+		resource: resource,
+		leaseID:  leaseID,
+		ctx:      leaseCtx,
+		cancel:   cancel,
+		stopCh:   make(chan struct{}),
+	}
+
+	now := time.Now()
+	m.mu.Lock()
+	m.locks[resource] = &heldLock{resource: resource, leaseID: leaseID, acquiredAt: now, expiresAt: now.Add(m.cfg.TTL)}
+	m.mu.Unlock()
+
+	go m.refreshLoop(lease)
+
+	return lease, nil
+}
+
+func (m *Manager) refreshLoop(lease *Lease) {
+	ticker := time.NewTicker(m.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-lease.stopCh:
+			return
+		case <-lease.ctx.Done():
+			return
+		case <-ticker.C:
+			// A refresh uses its own timeout rather than lease.ctx: it must
+			// not be cut short by the very cancellation it exists to guard
+			// against racing.
+			refreshCtx, cancel := context.WithTimeout(context.Background(), m.cfg.RefreshInterval)
+			err := m.backend.Refresh(refreshCtx, lease.resource, lease.leaseID, m.cfg.TTL)
+			cancel()
+			if err != nil {
+				failures++
+				m.log.Error(err, "failed to refresh lock lease", "resource", lease.resource, "failures", failures)
+				if failures >= m.cfg.MaxRefreshFailures {
+					m.log.Info("force-releasing lock after exhausting refresh retries", "resource", lease.resource)
+					m.forceRelease(lease)
+					return
+				}
+				continue
+			}
+			failures = 0
+
+			now := time.Now()
+			m.mu.Lock()
+			if held, ok := m.locks[lease.resource]; ok && held.leaseID == lease.leaseID {
+				held.expiresAt = now.Add(m.cfg.TTL)
+			}
+			m.mu.Unlock()
+		}
+	}
+}
+
+// forceRelease drops the lock both locally -- removing the bookkeeping entry
+// and cancelling the lease context so any waiter observes it immediately --
+// and remotely, on a best-effort basis.
+func (m *Manager) forceRelease(lease *Lease) {
+	m.mu.Lock()
+	if held, ok := m.locks[lease.resource]; ok && held.leaseID == lease.leaseID {
+		delete(m.locks, lease.resource)
+	}
+	m.mu.Unlock()
+
+	lease.cancel()
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), m.cfg.RefreshInterval)
+	defer cancel()
+	if err := m.backend.Release(releaseCtx, lease.resource, lease.leaseID); err != nil {
+		m.log.Error(err, "best-effort remote release failed after force-release", "resource", lease.resource)
+	}
+}
+
+// Release gives up the lease: it stops the background refresh goroutine and
+// releases the lock on the backend.
+func (l *Lease) Release(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stopCh) })
+	l.cancel()
+
+	l.manager.mu.Lock()
+	if held, ok := l.manager.locks[l.resource]; ok && held.leaseID == l.leaseID {
+		delete(l.manager.locks, l.resource)
+	}
+	l.manager.mu.Unlock()
+
+	return l.manager.backend.Release(ctx, l.resource, l.leaseID)
+}
+
+// TopLocks returns a snapshot of every lock currently held by this manager,
+// for operators diagnosing deadlocks across controller replicas.
+func (m *Manager) TopLocks() []LockInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	locks := make([]LockInfo, 0, len(m.locks))
+	for _, held := range m.locks {
+		locks = append(locks, LockInfo{
+			Resource:   held.resource,
+			LeaseID:    held.leaseID,
+			AcquiredAt: held.acquiredAt,
+			ExpiresAt:  held.expiresAt,
+		})
+	}
+	return locks
+}
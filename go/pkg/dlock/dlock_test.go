@@ -0,0 +1,106 @@
+package dlock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// failingBackend acquires and releases normally but fails every Refresh,
+// so a Manager configured against it exercises the force-release path.
+type failingBackend struct {
+	mu        sync.Mutex
+	refreshes int
+	released  []string
+}
+
+func (b *failingBackend) Acquire(ctx context.Context, resource string, ttl time.Duration) (string, error) {
+	return "lease-1", nil
+}
+
+func (b *failingBackend) Refresh(ctx context.Context, resource, leaseID string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refreshes++
+	return fmt.Errorf("refresh failed")
+}
+
+func (b *failingBackend) Release(ctx context.Context, resource, leaseID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.released = append(b.released, resource)
+	return nil
+}
+
+func TestManagerForceReleasesAfterMaxRefreshFailures(t *testing.T) {
+	backend := &failingBackend{}
+	manager := NewManager(backend, Config{
+		TTL:                20 * time.Millisecond,
+		RefreshInterval:    5 * time.Millisecond,
+		MaxRefreshFailures: 2,
+	}, logr.Discard())
+
+	lease, err := manager.Acquire(context.Background(), "res-1")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	select {
+	case <-lease.Done():
+	case <-time.After(time.Second):
+		t.Fatal("lease was not force-released after exhausting refresh retries")
+	}
+
+	if locks := manager.TopLocks(); len(locks) != 0 {
+		t.Fatalf("expected no locks held locally after force-release, got %v", locks)
+	}
+
+	// forceRelease cancels the lease before it releases remotely, so Done can
+	// close slightly before the backend sees the release.
+	var released []string
+	deadline := time.Now().Add(time.Second)
+	for {
+		backend.mu.Lock()
+		released = append([]string(nil), backend.released...)
+		backend.mu.Unlock()
+		if len(released) > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(released) != 1 || released[0] != "res-1" {
+		t.Fatalf("expected a best-effort remote release of res-1, got %v", released)
+	}
+}
+
+func TestManagerReleaseStopsRefreshWithoutForceRelease(t *testing.T) {
+	backend := &failingBackend{}
+	manager := NewManager(backend, Config{
+		TTL:                time.Hour,
+		RefreshInterval:    time.Hour,
+		MaxRefreshFailures: 1,
+	}, logr.Discard())
+
+	lease, err := manager.Acquire(context.Background(), "res-2")
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	if err := lease.Release(context.Background()); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+
+	select {
+	case <-lease.Done():
+	default:
+		t.Fatal("expected lease.Done() to be closed after an explicit Release")
+	}
+
+	if locks := manager.TopLocks(); len(locks) != 0 {
+		t.Fatalf("expected no locks held locally after Release, got %v", locks)
+	}
+}
@@ -0,0 +1,101 @@
+package dlock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+// PostgresBackend implements Backend using session-level advisory locks
+// (pg_try_advisory_lock). Unlike a Redis key, an advisory lock has no TTL of
+// its own: it lives as long as the backing connection does, so Acquire holds
+// a dedicated *sql.Conn from the pool for the lease's lifetime, and Refresh
+// pings that connection to keep it (and the lock) alive.
+type PostgresBackend struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	conns map[string]*sql.Conn // keyed by leaseID
+}
+
+// NewPostgresBackend creates a PostgresBackend over db.
+func NewPostgresBackend(db *sql.DB) *PostgresBackend {
+	return &PostgresBackend{db: db, conns: make(map[string]*sql.Conn)}
+}
+
+// advisoryKey hashes resource down to the int64 key pg_advisory_lock expects.
+func advisoryKey(resource string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(resource))
+	return int64(h.Sum64())
+}
+
+func (b *PostgresBackend) Acquire(ctx context.Context, resource string, ttl time.Duration) (string, error) {
+	conn, err := b.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryKey(resource)).Scan(&locked); err != nil {
+		conn.Close() //nolint:errcheck
+		return "", err
+	}
+	if !locked {
+		conn.Close() //nolint:errcheck
+		return "", ErrLocked
+	}
+
+	leaseID, err := newLeaseID()
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return "", err
+	}
+
+	b.mu.Lock()
+	b.conns[leaseID] = conn
+	b.mu.Unlock()
+
+	return leaseID, nil
+}
+
+func (b *PostgresBackend) conn(leaseID string) (*sql.Conn, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	conn, ok := b.conns[leaseID]
+	return conn, ok
+}
+
+// Refresh keeps the dedicated connection -- and therefore the advisory lock
+// -- alive. Postgres advisory locks don't expire on their own, so this is a
+// liveness check rather than a true TTL extension.
+func (b *PostgresBackend) Refresh(ctx context.Context, resource, leaseID string, ttl time.Duration) error {
+	conn, ok := b.conn(leaseID)
+	if !ok {
+		return fmt.Errorf("dlock: no connection held for lease %s", leaseID)
+	}
+	return conn.PingContext(ctx)
+}
+
+func (b *PostgresBackend) Release(ctx context.Context, resource, leaseID string) error {
+	b.mu.Lock()
+	conn, ok := b.conns[leaseID]
+	delete(b.conns, leaseID)
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	_, execErr := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryKey(resource))
+	closeErr := conn.Close()
+	if execErr != nil {
+		return execErr
+	}
+	return closeErr
+}
+
+var _ Backend = (*PostgresBackend)(nil)
@@ -0,0 +1,91 @@
+package dlock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend implements Backend on top of Redis: acquisition is a single
+// SET NX PX, and refresh/release run small Lua scripts so a holder can never
+// step on a lease it no longer owns (e.g. after its TTL already expired and
+// someone else acquired it).
+type RedisBackend struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBackend creates a RedisBackend. keyPrefix namespaces lock keys;
+// "kagent:dlock:" is used if empty.
+func NewRedisBackend(client *redis.Client, keyPrefix string) *RedisBackend {
+	if keyPrefix == "" {
+		keyPrefix = "kagent:dlock:"
+	}
+	return &RedisBackend{client: client, prefix: keyPrefix}
+}
+
+func (b *RedisBackend) key(resource string) string {
+	return b.prefix + resource
+}
+
+func (b *RedisBackend) Acquire(ctx context.Context, resource string, ttl time.Duration) (string, error) {
+	leaseID, err := newLeaseID()
+	if err != nil {
+		return "", err
+	}
+
+	ok, err := b.client.SetNX(ctx, b.key(resource), leaseID, ttl).Result()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", ErrLocked
+	}
+	return leaseID, nil
+}
+
+// refreshScript extends the key's TTL only if leaseID still owns it.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+func (b *RedisBackend) Refresh(ctx context.Context, resource, leaseID string, ttl time.Duration) error {
+	res, err := refreshScript.Run(ctx, b.client, []string{b.key(resource)}, leaseID, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return errors.New("dlock: lease is no longer owned")
+	}
+	return nil
+}
+
+// releaseScript deletes the key only if leaseID still owns it.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (b *RedisBackend) Release(ctx context.Context, resource, leaseID string) error {
+	_, err := releaseScript.Run(ctx, b.client, []string{b.key(resource)}, leaseID).Int()
+	return err
+}
+
+func newLeaseID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+var _ Backend = (*RedisBackend)(nil)